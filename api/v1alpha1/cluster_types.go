@@ -0,0 +1,480 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ConditionStatus is the status of a condition, mirroring corev1.ConditionStatus.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// ClusterConditionType is a stage of Cluster (or Role) reconciliation that can be
+// reported on independently, so a failure partway through is visible instead of
+// being swallowed into a generic requeue.
+type ClusterConditionType string
+
+const (
+	RolesReconciled    ClusterConditionType = "RolesReconciled"
+	LeaderElected      ClusterConditionType = "LeaderElected"
+	PodsJoined         ClusterConditionType = "PodsJoined"
+	WeightsApplied     ClusterConditionType = "WeightsApplied"
+	RolesApplied       ClusterConditionType = "RolesApplied"
+	VshardBootstrapped ClusterConditionType = "VshardBootstrapped"
+	FailoverEnabled    ClusterConditionType = "FailoverEnabled"
+	UpgradeProgressed  ClusterConditionType = "UpgradeProgressed"
+	ExpelSucceeded     ClusterConditionType = "ExpelSucceeded"
+	MaintenanceRun     ClusterConditionType = "MaintenanceRun"
+	JoinFailed         ClusterConditionType = "JoinFailed"
+	ConfigApplied      ClusterConditionType = "ConfigApplied"
+
+	// Ready reports whether every StatefulSet has a Ready pod.
+	Ready ClusterConditionType = "Ready"
+	// Progressing reports whether a rollout is still updating StatefulSets to the
+	// current ReplicasetTemplate revision.
+	Progressing ClusterConditionType = "Progressing"
+	// TemplateResolved reports whether Role's Selector currently matches exactly one
+	// ReplicasetTemplate.
+	TemplateResolved ClusterConditionType = "TemplateResolved"
+	// Degraded reports a reconciliation failure that needs operator attention, such as
+	// an unresolvable ReplicasetTemplate selector.
+	Degraded ClusterConditionType = "Degraded"
+)
+
+// ClusterCondition reports the status of a single reconciliation stage.
+type ClusterCondition struct {
+	// Type is the reconciliation stage this condition reports on.
+	Type ClusterConditionType `json:"type"`
+	// Status is True, False or Unknown for Type.
+	Status ConditionStatus `json:"status"`
+	// Reason is a short, machine-readable explanation for the condition's status.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable explanation for the condition's status.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time Status changed for Type.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// LastUpdateTime is the last time this condition was reported, whether or not Status changed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// SetCondition updates conditions with newCondition, preserving LastTransitionTime when
+// Status is unchanged and bumping it only when Status flips. LastUpdateTime is always
+// set to now so callers can tell how fresh a condition is even between flips.
+func SetCondition(conditions []ClusterCondition, newCondition ClusterCondition) []ClusterCondition {
+	now := metav1.Now()
+	newCondition.LastUpdateTime = now
+
+	for i, existing := range conditions {
+		if existing.Type != newCondition.Type {
+			continue
+		}
+		if existing.Status == newCondition.Status {
+			newCondition.LastTransitionTime = existing.LastTransitionTime
+		} else {
+			newCondition.LastTransitionTime = now
+		}
+		conditions[i] = newCondition
+		return conditions
+	}
+
+	newCondition.LastTransitionTime = now
+	return append(conditions, newCondition)
+}
+
+// LeaderElectionStrategy selects how ClusterReconciler picks which pod's address is
+// recorded as this Cluster's leader.
+type LeaderElectionStrategy string
+
+const (
+	// LeaderElectionFirstReady picks the first ready endpoint address, preserving
+	// the operator's original "first IP wins" behavior. This is the default.
+	LeaderElectionFirstReady LeaderElectionStrategy = "FirstReady"
+	// LeaderElectionLowestUUID picks the ready candidate with the lexicographically
+	// smallest tarantool.io/instance-uuid label, so the same pod keeps winning
+	// across reconciles regardless of endpoint address ordering.
+	LeaderElectionLowestUUID LeaderElectionStrategy = "LowestUUID"
+	// LeaderElectionHealthProbe picks the first ready candidate that answers a
+	// request against its Cartridge admin endpoint, so a pod that is Ready by
+	// kubelet's probe but unresponsive to Cartridge itself is skipped.
+	LeaderElectionHealthProbe LeaderElectionStrategy = "HealthProbe"
+)
+
+// ClusterSpec defines the desired state of Cluster
+type ClusterSpec struct {
+	// Selector matches the Role resources that belong to this Cluster.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Auth configures how the operator authenticates to this Cluster's Cartridge
+	// admin/topology API. If unset, requests are made without credentials over HTTP,
+	// matching Cartridge's default (auth disabled) configuration.
+	Auth *ClusterAuth `json:"auth,omitempty"`
+	// LeaderElection selects the strategy used to pick which pod's address is
+	// recorded as this Cluster's leader. Defaults to FirstReady.
+	LeaderElection LeaderElectionStrategy `json:"leaderElection,omitempty"`
+	// LeaderLeaseDuration is how long a coordination.k8s.io Lease holds a leader
+	// before it's considered expired and re-election is triggered. Defaults to 15s.
+	LeaderLeaseDuration *metav1.Duration `json:"leaderLeaseDuration,omitempty"`
+	// UpgradeStrategy configures how the operator replaces pods whose StatefulSet's
+	// pod template has drifted from what they were created with, and how it expels
+	// replicasets that have been scheduled for deletion after a downscale.
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+	// Maintenance confines expensive or disruptive operations (vshard rebalance,
+	// snapshot, failover re-assertion) to scheduled windows instead of running them
+	// on every reconcile.
+	Maintenance []MaintenanceWindow `json:"maintenance,omitempty"`
+	// ExpelTimeout bounds how long a replicaset scheduled for removal may sit
+	// draining its vshard buckets before the operator expels it anyway. Defaults to 5m.
+	ExpelTimeout *metav1.Duration `json:"expelTimeout,omitempty"`
+	// JoinTTL bounds how long a pod may hold a tarantool.io/instance-uuid label
+	// without joining the cluster before it's considered failed to join. Defaults to 15m.
+	JoinTTL *metav1.Duration `json:"joinTTL,omitempty"`
+	// AutoRemediate deletes a pod once it's exceeded JoinTTL without joining, so its
+	// StatefulSet recreates it with a fresh identity instead of it wedging the Cluster's
+	// reconcile forever. Defaults to false.
+	AutoRemediate bool `json:"autoRemediate,omitempty"`
+	// Failover configures Cartridge's cluster-wide failover. Leaving it unset preserves
+	// the operator's original behavior of enabling eventual failover once vshard is
+	// bootstrapped.
+	Failover *FailoverSpec `json:"failover,omitempty"`
+	// Config lists clusterwide configuration sections (vshard groups, role config,
+	// custom sections) to push to Cartridge, keyed by section filename. The controller
+	// applies only the sections whose content differs from what Cartridge currently
+	// has, via BuiltInTopologyService.ApplyConfig.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// FailoverMode selects Cartridge's cluster-wide failover mode.
+type FailoverMode string
+
+const (
+	// FailoverModeDisabled turns cluster-wide failover off.
+	FailoverModeDisabled FailoverMode = "disabled"
+	// FailoverModeEventual fails over to any healthy replica, without coordination
+	// between Cartridge instances, on eventual detection of the leader's failure.
+	FailoverModeEventual FailoverMode = "eventual"
+	// FailoverModeStateful coordinates failover through an external state provider
+	// (etcd2 or a stateboard), so all instances agree on the current leader even
+	// during a network partition. Requires StateProvider to be set.
+	FailoverModeStateful FailoverMode = "stateful"
+)
+
+// FailoverStateProviderType selects the backing store a FailoverModeStateful
+// coordinator stores its leader lock in.
+type FailoverStateProviderType string
+
+const (
+	// FailoverStateProviderETCD2 stores the leader lock in an external etcd cluster,
+	// configured by FailoverSpec.ETCD2.
+	FailoverStateProviderETCD2 FailoverStateProviderType = "etcd2"
+	// FailoverStateProviderStateboard stores the leader lock in a dedicated Tarantool
+	// stateboard instance, configured by FailoverSpec.Stateboard.
+	FailoverStateProviderStateboard FailoverStateProviderType = "stateboard"
+)
+
+// FailoverSpec configures Cartridge's cluster-wide failover mode and, for
+// FailoverModeStateful, the external state provider instances coordinate the current
+// leader through.
+type FailoverSpec struct {
+	// Mode selects Cartridge's failover mode. Defaults to FailoverModeEventual.
+	Mode FailoverMode `json:"mode,omitempty"`
+	// StateProvider selects the backing store for FailoverModeStateful. Required, and
+	// only consulted, when Mode is FailoverModeStateful.
+	StateProvider FailoverStateProviderType `json:"stateProvider,omitempty"`
+	// ETCD2 configures the etcd2 state provider. Required when StateProvider is
+	// FailoverStateProviderETCD2.
+	ETCD2 *ETCD2FailoverParams `json:"etcd2,omitempty"`
+	// Stateboard configures the stateboard state provider. Required when StateProvider
+	// is FailoverStateProviderStateboard.
+	Stateboard *StateboardFailoverParams `json:"stateboard,omitempty"`
+}
+
+// ETCD2FailoverParams configures Cartridge's etcd2 stateful failover coordinator.
+type ETCD2FailoverParams struct {
+	// Endpoints lists the etcd cluster's client URLs.
+	Endpoints []string `json:"endpoints,omitempty"`
+	// Prefix namespaces this cluster's failover keys within the etcd keyspace.
+	Prefix string `json:"prefix,omitempty"`
+	// LockDelay bounds how long a lost leader lock is held before another candidate
+	// may acquire it. Defaults to Cartridge's own default (10s).
+	LockDelay *metav1.Duration `json:"lockDelay,omitempty"`
+	// Username authenticates to etcd, alongside PasswordSecretRef.
+	Username string `json:"username,omitempty"`
+	// PasswordSecretRef references a Secret in the same namespace as the Cluster with
+	// a `password` key, used to authenticate to etcd alongside Username.
+	PasswordSecretRef *corev1.LocalObjectReference `json:"passwordSecretRef,omitempty"`
+}
+
+// StateboardFailoverParams configures Cartridge's stateboard stateful failover
+// coordinator.
+type StateboardFailoverParams struct {
+	// URI is the stateboard instance's advertise URI. Required unless Managed is set,
+	// in which case the controller derives it from the StatefulSet it creates.
+	URI string `json:"uri,omitempty"`
+	// PasswordSecretRef references a Secret in the same namespace as the Cluster with
+	// a `password` key, used to authenticate to the stateboard instance.
+	PasswordSecretRef *corev1.LocalObjectReference `json:"passwordSecretRef,omitempty"`
+	// Managed has the controller run the stateboard itself, as a single-replica
+	// StatefulSet owned by this Cluster, rather than expecting one to already be
+	// running at URI. Requires Image.
+	Managed bool `json:"managed,omitempty"`
+	// Image is the container image the managed stateboard StatefulSet runs. Required,
+	// and only consulted, when Managed is set.
+	Image string `json:"image,omitempty"`
+}
+
+// MaintenanceOperation is an action a Cluster's maintenance window gates.
+type MaintenanceOperation string
+
+const (
+	// MaintenanceOperationRebalance confines applying a StatefulSet's pending
+	// tarantool.io/replicaset-weight change -- and the vshard bucket rebalance it
+	// triggers -- to the configured window, instead of on every reconcile.
+	MaintenanceOperationRebalance MaintenanceOperation = "rebalance"
+	// MaintenanceOperationWeightApply is MaintenanceOperationRebalance's counterpart:
+	// the two gate the same weight-apply step, kept as distinct values so a Cluster's
+	// spec reads as "apply this weight change" or "let vshard rebalance", whichever
+	// the user is scheduling around.
+	MaintenanceOperationWeightApply MaintenanceOperation = "weightApply"
+	// MaintenanceOperationSnapshot calls the Cartridge admin API's snapshot mutation.
+	MaintenanceOperationSnapshot MaintenanceOperation = "snapshot"
+	// MaintenanceOperationFailoverProbe re-asserts the Cluster's configured failover
+	// mode, so a failover flag flipped out-of-band (or lost to a Cartridge restart)
+	// is caught and corrected on the next window instead of silently staying off.
+	MaintenanceOperationFailoverProbe MaintenanceOperation = "failoverProbe"
+	// MaintenanceOperationRolesDrift re-asserts every StatefulSet's
+	// tarantool.io/rolesToAssign roles against Cartridge, so a replicaset's roles
+	// changed out-of-band (e.g. an admin edited them directly, or a node rejoined with
+	// stale roles) are caught and corrected on the next window instead of only being
+	// noticed the next time something mutates the owning Role/ReplicasetTemplate.
+	MaintenanceOperationRolesDrift MaintenanceOperation = "rolesDrift"
+)
+
+// MaintenanceWindow confines one Operation to a cron Schedule, evaluated in TimeZone.
+type MaintenanceWindow struct {
+	// Operation is the action this window gates.
+	Operation MaintenanceOperation `json:"operation"`
+	// Schedule is a standard 5-field cron expression (as parsed by
+	// github.com/robfig/cron/v3's ParseStandard), e.g. "0 2 * * *" for every day at
+	// 02:00.
+	Schedule string `json:"schedule"`
+	// TimeZone is the IANA time zone name Schedule is evaluated in. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Next returns the first instant Schedule fires strictly after from, interpreted in
+// TimeZone (UTC if unset).
+func (w MaintenanceWindow) Next(from time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing schedule %q: %w", w.Schedule, err)
+	}
+
+	loc := time.UTC
+	if w.TimeZone != "" {
+		loc, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("loading time zone %q: %w", w.TimeZone, err)
+		}
+	}
+
+	return schedule.Next(from.In(loc)), nil
+}
+
+// UpgradeStrategyType selects how ClusterReconciler replaces a stale pod.
+type UpgradeStrategyType string
+
+const (
+	// UpgradeStrategyRollingWithDrain upgrades one pod per replicaset at a time: the
+	// target replicaset's weight is set to 0, the operator waits for its buckets to
+	// drain, then the pod is expelled and deleted so its StatefulSet recreates it at the
+	// current template. Replicasets with a stale pod are drained side by side, with no
+	// cap across the Cluster. This is the default.
+	UpgradeStrategyRollingWithDrain UpgradeStrategyType = "RollingWithDrain"
+	// UpgradeStrategyParallel behaves like RollingWithDrain but starts no new drain
+	// while MaxUnavailable pods across the Cluster are already draining.
+	UpgradeStrategyParallel UpgradeStrategyType = "Parallel"
+	// UpgradeStrategyRecreate deletes a stale pod immediately, without draining its
+	// replicaset's buckets first. Only appropriate when read/write availability
+	// during the upgrade doesn't matter.
+	UpgradeStrategyRecreate UpgradeStrategyType = "Recreate"
+)
+
+// UpgradeStrategy configures the Cluster's rolling upgrade and scheduled-expel behavior.
+type UpgradeStrategy struct {
+	// Type selects how stale pods are replaced. Defaults to RollingWithDrain.
+	Type UpgradeStrategyType `json:"type,omitempty"`
+	// MaxUnavailable caps how many pods across the Cluster may be draining or
+	// recreating at once. Only consulted by the Parallel strategy; RollingWithDrain
+	// and Recreate always act on one pod per replicaset at a time. Defaults to 1.
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+	// DrainTimeout bounds how long the operator waits, across reconciles, for a
+	// draining pod's buckets to reach zero before expelling it anyway. Defaults to 5m.
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// BucketRebalanceTimeout bounds how long the operator waits for vshard to
+	// rebalance buckets off a replicaset scheduled for deletion before expelling its
+	// pods anyway. Defaults to 5m.
+	BucketRebalanceTimeout *metav1.Duration `json:"bucketRebalanceTimeout,omitempty"`
+}
+
+// ClusterAuth configures credentials and TLS for the Cartridge admin/topology API.
+type ClusterAuth struct {
+	// SecretRef references a Secret in the same namespace as the Cluster. It must
+	// contain either `username`/`password` keys for HTTP basic auth, or a `token`
+	// key for bearer auth. `token` takes precedence when both are present.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// TLS configures how the operator verifies and authenticates to the Cartridge
+	// admin/topology API over HTTPS. Leaving it unset keeps requests on HTTP.
+	TLS *ClusterAuthTLS `json:"tls,omitempty"`
+}
+
+// ClusterAuthTLS configures TLS for the Cartridge admin/topology API.
+type ClusterAuthTLS struct {
+	// CASecretRef references a Secret with a `ca.crt` key holding the CA bundle used
+	// to verify the Cartridge admin API's server certificate.
+	CASecretRef *corev1.LocalObjectReference `json:"caSecretRef,omitempty"`
+	// ClientCertSecretRef references a Secret with `tls.crt`/`tls.key` keys used for
+	// mutual TLS to the Cartridge admin API.
+	ClientCertSecretRef *corev1.LocalObjectReference `json:"clientCertSecretRef,omitempty"`
+	// InsecureSkipVerify disables verification of the Cartridge admin API's server
+	// certificate. Only intended for development clusters.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ClusterStatus defines the observed state of Cluster
+type ClusterStatus struct {
+	// State is a short, human-readable summary of the cluster's state, kept for
+	// backwards compatibility with existing tooling. Conditions carries the detail.
+	State string `json:"state,omitempty"`
+	// ObservedGeneration is the most recent Cluster generation the controller has acted on.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions reports the status of each reconciliation stage the controller performs.
+	Conditions []ClusterCondition `json:"conditions,omitempty"`
+	// UpgradeProgress reports how far the rolling upgrade / scheduled-expel phase has
+	// gotten, so `kubectl get` can show progress across replicasets.
+	UpgradeProgress *UpgradeProgress `json:"upgradeProgress,omitempty"`
+	// Leader reports the Cluster's current admin instance, as tracked by the
+	// coordination.k8s.io Lease named after this Cluster.
+	Leader *ClusterLeader `json:"leader,omitempty"`
+	// NextScheduledRuns reports, for each Operation configured in Spec.Maintenance,
+	// the next instant its window fires. The controller requeues for the earliest of
+	// these instead of its default poll interval, so a maintenance window isn't missed
+	// between reconciles.
+	NextScheduledRuns map[MaintenanceOperation]metav1.Time `json:"nextScheduledRuns,omitempty"`
+	// Failover reports the failover configuration Cartridge confirmed as active the
+	// last time the controller applied Spec.Failover.
+	Failover *FailoverStatus `json:"failover,omitempty"`
+	// Federation identifies the member Kubernetes cluster this Cluster was fanned out
+	// to by a FederatedCluster placement, if any. Unset for a Cluster reconciled
+	// locally (directly, or as a local-standin placement with no KubeconfigSecretRef).
+	Federation *FederationStatus `json:"federation,omitempty"`
+}
+
+// FederationStatus reports which FederatedCluster placement produced this Cluster, and
+// which member Kubernetes cluster it's reconciled in.
+type FederationStatus struct {
+	// FederatedClusterName is the name of the FederatedCluster that created this
+	// Cluster via a placement.
+	FederatedClusterName string `json:"federatedClusterName,omitempty"`
+	// MemberClusterName is the ClusterPlacement.ClusterName this Cluster was fanned
+	// out to, identifying which remote Kubernetes cluster it lives in.
+	MemberClusterName string `json:"memberClusterName,omitempty"`
+}
+
+// FailoverStatus reports the failover configuration Cartridge has confirmed active.
+type FailoverStatus struct {
+	// Mode is Cartridge's currently active failover mode.
+	Mode FailoverMode `json:"mode,omitempty"`
+	// StateProvider is the active stateful failover state provider, set only when Mode
+	// is FailoverModeStateful.
+	StateProvider FailoverStateProviderType `json:"stateProvider,omitempty"`
+}
+
+// ClusterLeader reports the holder of a Cluster's leader Lease.
+type ClusterLeader struct {
+	// Pod is the leader's address, in "ip:adminPort" form.
+	Pod string `json:"pod,omitempty"`
+	// Since is when the current holder was first acquired.
+	Since *metav1.Time `json:"since,omitempty"`
+	// ExpiresAt is when the Lease must next be renewed to keep this holder current.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// UpgradeProgress reports the state of the Cluster's rolling upgrade phase.
+type UpgradeProgress struct {
+	// Phase is a short, human-readable summary of what the upgrade phase is
+	// currently doing, e.g. "Draining", "Expelling", "UpToDate".
+	Phase string `json:"phase,omitempty"`
+	// Replicaset is the StatefulSet currently being drained or expelled, if any.
+	Replicaset string `json:"replicaset,omitempty"`
+	// Pod is the specific pod currently being drained or expelled, if any.
+	Pod string `json:"pod,omitempty"`
+	// ReplicasUpgraded is how many pods have been recreated at the current pod
+	// template since the upgrade phase started making progress.
+	ReplicasUpgraded int32 `json:"replicasUpgraded,omitempty"`
+	// ReplicasTotal is how many pods in the Cluster are tracked for this upgrade.
+	ReplicasTotal int32 `json:"replicasTotal,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// Cluster is the Schema for the clusters API
+type Cluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSpec   `json:"spec,omitempty"`
+	Status ClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterList contains a list of Cluster
+type ClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Cluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Cluster{}, &ClusterList{})
+}
@@ -0,0 +1,242 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// webhookClient is used by the validating webhooks in this package to look up related
+// objects (e.g. a Cluster's Roles) that admission.Validator's interface, unlike a
+// Reconciler, doesn't get handed directly. Set once by whichever *_webhook.go's
+// SetupWebhookWithManager runs first.
+var webhookClient client.Client
+
+// SetupWebhookWithManager registers Cluster's defaulting and validating webhooks with
+// mgr.
+func (c *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookClient = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(c).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-tarantool-io-v1alpha1-cluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=tarantool.io,resources=clusters,verbs=create;update,versions=v1alpha1,name=mcluster.kb.io,admissionReviewVersions=v1
+
+// Cluster satisfies admission.Defaulter/Validator via zz_generated.deepcopy.go's
+// DeepCopyObject; the assertions below don't compile without it.
+var _ webhook.Defaulter = &Cluster{}
+
+// Default implements webhook.Defaulter, filling in Spec.Failover.Mode so downstream
+// code (buildFailoverConfig, validateFailover) always sees an explicit mode rather than
+// having to special-case the empty string as FailoverModeEventual.
+func (c *Cluster) Default() {
+	if c.Spec.Failover != nil && c.Spec.Failover.Mode == "" {
+		c.Spec.Failover.Mode = FailoverModeEventual
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-tarantool-io-v1alpha1-cluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=tarantool.io,resources=clusters,verbs=create;update,versions=v1alpha1,name=vcluster.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Cluster{}
+
+// ValidateCreate implements webhook.Validator, rejecting a Cluster whose
+// Spec.Maintenance can't be parsed or whose Spec.Selector is unset before it's ever
+// persisted. Spec.Selector isn't checked against existing Roles here, since a Cluster
+// is routinely created before the Roles that will match it.
+func (c *Cluster) ValidateCreate() error {
+	if c.Spec.Selector == nil {
+		return fmt.Errorf("spec.selector is required")
+	}
+	if err := c.validateClusterIDUnique(); err != nil {
+		return err
+	}
+	if err := c.validateMaintenance(); err != nil {
+		return err
+	}
+	return c.validateFailover()
+}
+
+// ValidateUpdate implements webhook.Validator. In addition to ValidateCreate's checks,
+// it rejects a Selector change that leaves the Cluster matching no Role, since by
+// update time the Cluster's Roles are expected to already exist, and RoleReconciler
+// silently orphans them instead of erroring when that happens.
+func (c *Cluster) ValidateUpdate(old runtime.Object) error {
+	if c.Spec.Selector == nil {
+		return fmt.Errorf("spec.selector is required")
+	}
+
+	if err := c.validateSelectorMatchesRole(); err != nil {
+		return err
+	}
+
+	if err := c.validateClusterIDUnique(); err != nil {
+		return err
+	}
+
+	if err := c.validateMaintenance(); err != nil {
+		return err
+	}
+
+	return c.validateFailover()
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is never rejected.
+func (c *Cluster) ValidateDelete() error {
+	return nil
+}
+
+// validateSelectorMatchesRole rejects a Spec.Selector that matches no Role in the
+// Cluster's namespace. webhookClient is nil in contexts that construct a Cluster
+// without going through SetupWebhookWithManager (e.g. unit tests), where this check is
+// skipped rather than failing closed.
+func (c *Cluster) validateSelectorMatchesRole() error {
+	if webhookClient == nil {
+		return nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(c.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("spec.selector: %w", err)
+	}
+
+	roleList := &RoleList{}
+	if err := webhookClient.List(context.Background(), roleList, client.InNamespace(c.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("listing Roles for spec.selector: %w", err)
+	}
+
+	if len(roleList.Items) == 0 {
+		return fmt.Errorf("spec.selector matches no Role in namespace %q", c.GetNamespace())
+	}
+
+	return nil
+}
+
+// clusterIDLabel is the label key a Cluster's Spec.Selector, and the Roles/
+// ReplicasetTemplates it matches, carry to tie them to one Cartridge topology.
+const clusterIDLabel = "tarantool.io/cluster-id"
+
+// validateClusterIDUnique rejects a Spec.Selector whose tarantool.io/cluster-id match
+// label collides with another Cluster in the same namespace. Two Clusters sharing a
+// cluster-id would both reconcile the same Cartridge topology against each other's
+// Roles, so this is checked on both create and update. webhookClient is nil in
+// contexts that construct a Cluster without going through SetupWebhookWithManager
+// (e.g. unit tests), where this check is skipped rather than failing closed.
+func (c *Cluster) validateClusterIDUnique() error {
+	if webhookClient == nil {
+		return nil
+	}
+
+	clusterID, ok := clusterIDFromSelector(c.Spec.Selector)
+	if !ok {
+		return nil
+	}
+
+	clusterList := &ClusterList{}
+	if err := webhookClient.List(context.Background(), clusterList, client.InNamespace(c.GetNamespace())); err != nil {
+		return fmt.Errorf("listing Clusters to check spec.selector's %s: %w", clusterIDLabel, err)
+	}
+
+	for _, other := range clusterList.Items {
+		if other.GetName() == c.GetName() {
+			continue
+		}
+		if otherID, ok := clusterIDFromSelector(other.Spec.Selector); ok && otherID == clusterID {
+			return fmt.Errorf("spec.selector's %s %q is already used by Cluster %q in namespace %q", clusterIDLabel, clusterID, other.GetName(), c.GetNamespace())
+		}
+	}
+
+	return nil
+}
+
+// clusterIDFromSelector reads the cluster-id match label off selector, returning false
+// if selector is unset or doesn't set one.
+func clusterIDFromSelector(selector *metav1.LabelSelector) (string, bool) {
+	if selector == nil {
+		return "", false
+	}
+	id, ok := selector.MatchLabels[clusterIDLabel]
+	return id, ok
+}
+
+// validateMaintenance rejects an unknown Operation or a Schedule/TimeZone that
+// MaintenanceWindow.Next can't parse, so a typo surfaces at admission time instead of
+// as a silently-skipped maintenance window.
+func (c *Cluster) validateMaintenance() error {
+	for i, window := range c.Spec.Maintenance {
+		switch window.Operation {
+		case MaintenanceOperationRebalance, MaintenanceOperationWeightApply, MaintenanceOperationSnapshot, MaintenanceOperationFailoverProbe, MaintenanceOperationRolesDrift:
+		default:
+			return fmt.Errorf("spec.maintenance[%d].operation: unknown operation %q", i, window.Operation)
+		}
+
+		if _, err := window.Next(time.Now()); err != nil {
+			return fmt.Errorf("spec.maintenance[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateFailover rejects a Spec.Failover that requests FailoverModeStateful without a
+// StateProvider, or a StateProvider whose matching params block is missing, so a
+// misconfigured stateful failover surfaces at admission time instead of as a rejected
+// Cartridge mutation during reconcile.
+func (c *Cluster) validateFailover() error {
+	failover := c.Spec.Failover
+	if failover == nil || failover.Mode != FailoverModeStateful {
+		return nil
+	}
+
+	switch failover.StateProvider {
+	case FailoverStateProviderETCD2:
+		if failover.ETCD2 == nil || len(failover.ETCD2.Endpoints) == 0 {
+			return fmt.Errorf("spec.failover.etcd2.endpoints is required when spec.failover.stateProvider is %q", FailoverStateProviderETCD2)
+		}
+	case FailoverStateProviderStateboard:
+		if failover.Stateboard == nil || (!failover.Stateboard.Managed && failover.Stateboard.URI == "") {
+			return fmt.Errorf("spec.failover.stateboard.uri is required when spec.failover.stateProvider is %q, unless spec.failover.stateboard.managed is set", FailoverStateProviderStateboard)
+		}
+		if failover.Stateboard.Managed && failover.Stateboard.Image == "" {
+			return fmt.Errorf("spec.failover.stateboard.image is required when spec.failover.stateboard.managed is set")
+		}
+	default:
+		return fmt.Errorf("spec.failover.stateProvider is required when spec.failover.mode is %q", FailoverModeStateful)
+	}
+
+	return nil
+}
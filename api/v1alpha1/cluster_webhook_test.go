@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// newClusterWebhookScheme returns a Scheme carrying both this package's types and
+// corev1, for webhook tests whose fake webhookClient needs to List Pods (e.g.
+// Role.ValidateDelete) alongside Clusters/Roles/ReplicasetTemplates.
+func newClusterWebhookScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+var _ = Describe("Cluster validating webhook", func() {
+	var namespace = "cluster-webhook-test"
+
+	AfterEach(func() {
+		webhookClient = nil
+	})
+
+	clusterWithSelector := func(name, clusterID string) *Cluster {
+		return &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: ClusterSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{clusterIDLabel: clusterID}},
+			},
+		}
+	}
+
+	Describe("ValidateCreate", func() {
+		DescribeTable("rejects invalid Clusters",
+			func(c *Cluster, existing []runtime.Object, wantErr string) {
+				webhookClient = fake.NewClientBuilder().WithScheme(newClusterWebhookScheme()).WithRuntimeObjects(existing...).Build()
+
+				err := c.ValidateCreate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(wantErr))
+			},
+			Entry("missing spec.selector",
+				&Cluster{ObjectMeta: metav1.ObjectMeta{Name: "no-selector", Namespace: namespace}},
+				nil,
+				"spec.selector is required",
+			),
+			Entry("duplicate cluster-id",
+				clusterWithSelector("new-cluster", "dup-id"),
+				[]runtime.Object{clusterWithSelector("existing-cluster", "dup-id")},
+				`"dup-id" is already used by Cluster "existing-cluster"`,
+			),
+			Entry("unparseable maintenance schedule",
+				&Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "bad-maintenance", Namespace: namespace},
+					Spec: ClusterSpec{
+						Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{clusterIDLabel: "bad-maintenance"}},
+						Maintenance: []MaintenanceWindow{{Operation: MaintenanceOperationSnapshot, Schedule: "not-a-cron"}},
+					},
+				},
+				nil,
+				"spec.maintenance[0]",
+			),
+			Entry("stateful failover without a state provider",
+				&Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "bad-failover", Namespace: namespace},
+					Spec: ClusterSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{clusterIDLabel: "bad-failover"}},
+						Failover: &FailoverSpec{Mode: FailoverModeStateful},
+					},
+				},
+				nil,
+				"spec.failover.stateProvider is required",
+			),
+		)
+
+		It("accepts a Cluster whose cluster-id is unique in the namespace", func() {
+			webhookClient = fake.NewClientBuilder().WithScheme(newClusterWebhookScheme()).Build()
+			c := clusterWithSelector("solo-cluster", "solo-id")
+			Expect(c.ValidateCreate()).NotTo(HaveOccurred())
+		})
+
+		It("skips the cluster-id check when webhookClient is unset", func() {
+			webhookClient = nil
+			c := clusterWithSelector("unset-client", "any-id")
+			Expect(c.ValidateCreate()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		It("rejects a cluster-id collision introduced by the update", func() {
+			existing := clusterWithSelector("existing-cluster", "taken-id")
+			updated := clusterWithSelector("updated-cluster", "taken-id")
+			matchingRole := &Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "matching-role",
+					Namespace: namespace,
+					Labels:    map[string]string{clusterIDLabel: "taken-id"},
+				},
+			}
+			webhookClient = fake.NewClientBuilder().WithScheme(newClusterWebhookScheme()).WithObjects(existing, updated, matchingRole).Build()
+
+			err := updated.ValidateUpdate(existing)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is already used by Cluster"))
+		})
+	})
+})
@@ -0,0 +1,122 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+//
+// FederatedCluster is a first cut at describing a Tarantool cluster that spans more
+// than one Kubernetes cluster. A placement with a KubeconfigSecretRef is fanned out
+// via a controller-runtime cluster.Cluster built from that Secret (see
+// controllers/federation.ClusterFromSecret and FederatedClusterReconciler); a
+// placement without one is still reconciled locally, namespaced by placement name, the
+// way every placement used to be before member clusters were wired in.
+
+// ClusterPlacementOverride patches the per-member-cluster shape of a federated
+// Cluster: how many replicasets it gets, their vshard weight, and which
+// availability zone they should be scheduled into.
+type ClusterPlacementOverride struct {
+	// NumReplicasets overrides the replicaset count for this placement's member cluster.
+	NumReplicasets *int32 `json:"numReplicasets,omitempty"`
+	// Weight overrides the vshard bucket weight assigned to replicasets in this placement.
+	Weight *int32 `json:"weight,omitempty"`
+	// Zone tags pods scheduled under this placement so Cartridge can reason about
+	// availability zones when placing vshard buckets across member clusters.
+	Zone string `json:"zone,omitempty"`
+}
+
+// ClusterPlacement names one member cluster and how the federated Cluster should be
+// shaped there.
+type ClusterPlacement struct {
+	// ClusterName is the name of the member cluster this placement targets. It is
+	// also used to namespace the local stand-in Cluster when KubeconfigSecretRef is
+	// unset.
+	ClusterName string `json:"clusterName"`
+	// Override holds replicaset count/weight/zone values for this member cluster.
+	// FederatedClusterReconciler does not apply it: every placement gets Template unchanged.
+	Override *ClusterPlacementOverride `json:"override,omitempty"`
+	// KubeconfigSecretRef names a Secret holding this placement's member-cluster
+	// kubeconfig under its "kubeconfig" key. Unlike ClusterAuth's same-namespace
+	// LocalObjectReferences, this may point at a different namespace than the
+	// FederatedCluster, since the Secret is often delivered by a separate
+	// cluster-registration process. When unset, this placement's Cluster is
+	// reconciled locally instead of fanned out to a member cluster.
+	KubeconfigSecretRef *corev1.SecretReference `json:"kubeconfigSecretRef,omitempty"`
+}
+
+// FederatedClusterSpec defines the desired state of FederatedCluster
+type FederatedClusterSpec struct {
+	// Template is the Cluster spec replicated unchanged into every member cluster; Placements' Overrides are not applied to it.
+	Template ClusterSpec `json:"template,omitempty"`
+	// Placements lists the member clusters this FederatedCluster should be reconciled into.
+	Placements []ClusterPlacement `json:"placements,omitempty"`
+	// Topology names which Roles are expected to live in which placements, keyed by
+	// ClusterPlacement.ClusterName. This is advisory metadata for operators and
+	// dashboards; FederatedClusterReconciler does not yet enforce it.
+	Topology map[string][]string `json:"topology,omitempty"`
+}
+
+// FederatedClusterStatus defines the observed state of FederatedCluster
+type FederatedClusterStatus struct {
+	// ObservedGeneration is the most recent FederatedCluster generation the controller has acted on.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// PlacementStatuses aggregates each placement's underlying Cluster.Status.Conditions,
+	// keyed by ClusterPlacement.ClusterName.
+	PlacementStatuses map[string][]ClusterCondition `json:"placementStatuses,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// FederatedCluster is the Schema for the federatedclusters API
+type FederatedCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FederatedClusterSpec   `json:"spec,omitempty"`
+	Status FederatedClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FederatedClusterList contains a list of FederatedCluster
+type FederatedClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FederatedCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FederatedCluster{}, &FederatedClusterList{})
+}
@@ -0,0 +1,109 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+//
+// PreflightCheck lets an operator ask, ahead of admitting any Cluster/Role/
+// ReplicasetTemplate into TargetNamespace, whether the namespace is actually ready for
+// one: CRDs installed, RBAC granted, in-cluster DNS resolvable. The storage class
+// check always reports a skipped Pass, since PreflightCheckReconciler passes it no
+// storage class, and no PodSecurity/PSP check is run. See controllers/preflight for the
+// check implementations and PreflightCheckReconciler for how Status.Results is populated.
+
+// PreflightResultStatus is the outcome of a single preflight check.
+type PreflightResultStatus string
+
+const (
+	// PreflightPass means the check's prerequisite is satisfied.
+	PreflightPass PreflightResultStatus = "Pass"
+	// PreflightWarn means the check found something worth an operator's attention,
+	// but not severe enough to block admission.
+	PreflightWarn PreflightResultStatus = "Warn"
+	// PreflightFail means the check's prerequisite is missing; admission should be
+	// treated as unsafe until it's addressed.
+	PreflightFail PreflightResultStatus = "Fail"
+)
+
+// PreflightCheckResult is one named check's outcome.
+type PreflightCheckResult struct {
+	// Name identifies the check, e.g. "CRDsInstalled" or "AdvertiseDNSResolves".
+	Name string `json:"name"`
+	// Status is Pass, Warn, or Fail.
+	Status PreflightResultStatus `json:"status"`
+	// Message explains Status in human-readable terms.
+	Message string `json:"message,omitempty"`
+	// Remediation suggests how to fix a Warn or Fail result.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// PreflightCheckSpec defines the desired state of PreflightCheck
+type PreflightCheckSpec struct {
+	// TargetNamespace is the namespace the check battery is run against. Defaults to
+	// the PreflightCheck's own namespace when unset.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+}
+
+// PreflightCheckStatus defines the observed state of PreflightCheck
+type PreflightCheckStatus struct {
+	// ObservedGeneration is the most recent PreflightCheck generation the controller has acted on.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Results holds every check's outcome from the most recent run.
+	Results []PreflightCheckResult `json:"results,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// PreflightCheck is the Schema for the preflightchecks API
+type PreflightCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PreflightCheckSpec   `json:"spec,omitempty"`
+	Status PreflightCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PreflightCheckList contains a list of PreflightCheck
+type PreflightCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PreflightCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PreflightCheck{}, &PreflightCheckList{})
+}
@@ -30,6 +30,7 @@ package v1alpha1
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -72,3 +73,55 @@ type ReplicasetTemplateList struct {
 func init() {
 	SchemeBuilder.Register(&ReplicasetTemplate{}, &ReplicasetTemplateList{})
 }
+
+const (
+	// MainContainerName is the container name CreateStatefulSetFromTemplate and
+	// RoleReconciler look for when a ReplicasetTemplate doesn't carry
+	// MainContainerAnnotation.
+	MainContainerName = "tarantool"
+	// MainContainerAnnotation names the container, among a ReplicasetTemplate's pod
+	// template containers, that's actually running Tarantool -- the one whose image,
+	// env, and security context RoleReconciler manages. Set this when the Tarantool
+	// container isn't named MainContainerName, or isn't first in the container list
+	// (e.g. a sidecar or init-container-style entry comes first).
+	MainContainerAnnotation = "tarantool.io/mainContainer"
+)
+
+// MainContainerIndex returns the index of rs's Tarantool container among its pod
+// template's containers. ok is false only when the pod template has no containers at
+// all; a ReplicasetTemplate whose MainContainerAnnotation names a container that isn't
+// actually present falls back the same way an unset annotation would, to
+// MainContainerName, then index 0, so an old ReplicasetTemplate predating this lookup
+// keeps behaving exactly as it did when Containers[0] was always assumed to be it.
+func (rs *ReplicasetTemplate) MainContainerIndex() (int, bool) {
+	return MainContainerIndexIn(rs.GetAnnotations()[MainContainerAnnotation], rs.Spec.Template.Spec.Containers)
+}
+
+// MainContainerIndexIn returns the index of the container named name within
+// containers, falling back to MainContainerName, then index 0, when name is empty or
+// matches nothing. Exported so RoleReconciler can apply the same lookup to a
+// StatefulSet's own (copied-from-template) container list, which doesn't carry
+// MainContainerAnnotation itself. ok is false only when containers is empty.
+func MainContainerIndexIn(name string, containers []corev1.Container) (int, bool) {
+	if len(containers) == 0 {
+		return 0, false
+	}
+
+	if name == "" {
+		name = MainContainerName
+	}
+	for i, c := range containers {
+		if c.Name == name {
+			return i, true
+		}
+	}
+	if name != MainContainerName {
+		for i, c := range containers {
+			if c.Name == MainContainerName {
+				return i, true
+			}
+		}
+	}
+
+	return 0, true
+}
@@ -0,0 +1,140 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers ReplicasetTemplate's validating webhook with mgr.
+func (rs *ReplicasetTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookClient = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(rs).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-tarantool-io-v1alpha1-replicasettemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=tarantool.io,resources=replicasettemplates,verbs=create;update,versions=v1alpha1,name=vreplicasettemplate.kb.io,admissionReviewVersions=v1
+
+// ReplicasetTemplate satisfies admission.Validator via zz_generated.deepcopy.go's
+// DeepCopyObject; the assertion below doesn't compile without it.
+var _ webhook.Validator = &ReplicasetTemplate{}
+
+// ValidateCreate implements webhook.Validator, rejecting a ReplicasetTemplate whose
+// Spec or pod template's container list is empty and a negative Spec.Replicas --
+// CreateStatefulSetFromTemplate dereferences both without a nil/bounds check.
+func (rs *ReplicasetTemplate) ValidateCreate() error {
+	return rs.validateSpec()
+}
+
+// AllowImageChangeAnnotation opts a ReplicasetTemplate update into changing one of its
+// pod template's container images. Without it, ValidateUpdate rejects the change --
+// RoleReconciler rolls a new image out to every StatefulSet the template matches, so an
+// unrelated edit (resources, env) shouldn't silently carry an image bump along with it.
+const AllowImageChangeAnnotation = "tarantool.io/allow-image-change"
+
+// ValidateUpdate implements webhook.Validator.
+func (rs *ReplicasetTemplate) ValidateUpdate(old runtime.Object) error {
+	if err := rs.validateSpec(); err != nil {
+		return err
+	}
+
+	oldRS, ok := old.(*ReplicasetTemplate)
+	if !ok {
+		return fmt.Errorf("expected old object to be a ReplicasetTemplate, got %T", old)
+	}
+
+	return rs.validateImageChange(oldRS)
+}
+
+// ValidateDelete implements webhook.Validator. Deletion is never rejected.
+func (rs *ReplicasetTemplate) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec rejects a nil Spec, a pod template with no containers, a
+// MainContainerAnnotation naming a container that isn't in the pod template, and a
+// negative Spec.Replicas.
+func (rs *ReplicasetTemplate) validateSpec() error {
+	if rs.Spec == nil {
+		return fmt.Errorf("spec is required")
+	}
+	if len(rs.Spec.Template.Spec.Containers) == 0 {
+		return fmt.Errorf("spec.template.spec.containers is required")
+	}
+	if name := rs.GetAnnotations()[MainContainerAnnotation]; name != "" {
+		if _, ok := findContainer(name, rs.Spec.Template.Spec.Containers); !ok {
+			return fmt.Errorf("%s annotation names container %q, which is not in spec.template.spec.containers", MainContainerAnnotation, name)
+		}
+	}
+	if rs.Spec.Replicas != nil && *rs.Spec.Replicas < 0 {
+		return fmt.Errorf("spec.replicas must not be negative, got %d", *rs.Spec.Replicas)
+	}
+
+	return nil
+}
+
+// validateImageChange rejects a container image change from old to rs unless
+// AllowImageChangeAnnotation is set, matching containers by name. Containers only in
+// one of the two (added/removed alongside the rename) aren't compared.
+func (rs *ReplicasetTemplate) validateImageChange(old *ReplicasetTemplate) error {
+	if rs.GetAnnotations()[AllowImageChangeAnnotation] != "" {
+		return nil
+	}
+	if rs.Spec == nil || old.Spec == nil {
+		return nil
+	}
+
+	oldImages := make(map[string]string, len(old.Spec.Template.Spec.Containers))
+	for _, c := range old.Spec.Template.Spec.Containers {
+		oldImages[c.Name] = c.Image
+	}
+
+	for _, c := range rs.Spec.Template.Spec.Containers {
+		if oldImage, ok := oldImages[c.Name]; ok && oldImage != c.Image {
+			return fmt.Errorf("spec.template.spec.containers[%q].image changed from %q to %q without the %s annotation", c.Name, oldImage, c.Image, AllowImageChangeAnnotation)
+		}
+	}
+
+	return nil
+}
+
+func findContainer(name string, containers []corev1.Container) (corev1.Container, bool) {
+	for _, c := range containers {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return corev1.Container{}, false
+}
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func replicasetTemplateWithImage(name, image string, annotations map[string]string) *ReplicasetTemplate {
+	return &ReplicasetTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+		Spec: &appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "tarantool", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("ReplicasetTemplate validating webhook", func() {
+	Describe("ValidateCreate", func() {
+		DescribeTable("rejects invalid ReplicasetTemplates",
+			func(rs *ReplicasetTemplate, wantErr string) {
+				err := rs.ValidateCreate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(wantErr))
+			},
+			Entry("nil spec",
+				&ReplicasetTemplate{ObjectMeta: metav1.ObjectMeta{Name: "nil-spec", Namespace: "default"}},
+				"spec is required",
+			),
+			Entry("no containers",
+				&ReplicasetTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: "no-containers", Namespace: "default"},
+					Spec:       &appsv1.StatefulSetSpec{},
+				},
+				"spec.template.spec.containers is required",
+			),
+			Entry("negative replicas",
+				func() *ReplicasetTemplate {
+					rs := replicasetTemplateWithImage("negative-replicas", "tarantool:2.10", nil)
+					n := int32(-1)
+					rs.Spec.Replicas = &n
+					return rs
+				}(),
+				"spec.replicas must not be negative",
+			),
+		)
+
+		It("accepts a valid ReplicasetTemplate", func() {
+			rs := replicasetTemplateWithImage("valid", "tarantool:2.10", nil)
+			Expect(rs.ValidateCreate()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		It("rejects a container image change without the allow-image-change annotation", func() {
+			old := replicasetTemplateWithImage("rs", "tarantool:2.10", nil)
+			updated := replicasetTemplateWithImage("rs", "tarantool:2.11", nil)
+
+			err := updated.ValidateUpdate(old)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`changed from "tarantool:2.10" to "tarantool:2.11"`))
+			Expect(err.Error()).To(ContainSubstring(AllowImageChangeAnnotation))
+		})
+
+		It("accepts a container image change with the allow-image-change annotation", func() {
+			old := replicasetTemplateWithImage("rs", "tarantool:2.10", nil)
+			updated := replicasetTemplateWithImage("rs", "tarantool:2.11", map[string]string{AllowImageChangeAnnotation: "true"})
+
+			Expect(updated.ValidateUpdate(old)).NotTo(HaveOccurred())
+		})
+
+		It("accepts an update that doesn't change the image", func() {
+			old := replicasetTemplateWithImage("rs", "tarantool:2.10", nil)
+			updated := replicasetTemplateWithImage("rs", "tarantool:2.10", nil)
+
+			Expect(updated.ValidateUpdate(old)).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,225 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// RoleSpec defines the desired state of Role
+type RoleSpec struct {
+	// NumReplicasets is the number of StatefulSets (Tarantool replicasets) created under this Role.
+	NumReplicasets *int32 `json:"numReplicasets,omitempty"`
+	// Selector is a LabelSelector to find the ReplicasetTemplate resources from which StatefulSets are created.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// RolesToAssign lists the Cartridge roles (e.g. "vshard-router", "vshard-storage")
+	// this Role's replicasets should join with. Takes precedence over the deprecated
+	// tarantool.io/rolesToAssign annotation/label on the matched ReplicasetTemplate,
+	// which is still honored when this is unset so existing manifests keep working.
+	// +kubebuilder:validation:MinItems=1
+	RolesToAssign []string `json:"rolesToAssign,omitempty"`
+	// VshardGroup names the vshard storage group this Role's replicasets belong to,
+	// for clusters with more than one storage group. Only meaningful alongside a
+	// "vshard-storage" entry in RolesToAssign.
+	VshardGroup *string `json:"vshardGroup,omitempty"`
+	// Weight is the vshard bucket weight assigned to this Role's replicasets.
+	// Defaults to 100 when unset. Only meaningful alongside a "vshard-storage" entry
+	// in RolesToAssign.
+	// +kubebuilder:validation:Minimum=0
+	Weight *int `json:"weight,omitempty"`
+	// PodOverlay carries per-Role additions that are merged into the StatefulSet's pod
+	// template on top of the matched ReplicasetTemplate, so operators can attach
+	// sidecars, env vars, or scheduling constraints without cloning the whole template.
+	PodOverlay *PodOverlay `json:"podOverlay,omitempty"`
+	// RoleScheduling controls whether this Role's tarantool.io/rolesToAssign roles are
+	// translated into node affinity and tolerations on its StatefulSet pods, so e.g.
+	// vshard storages can be pinned to memory-optimized nodes without a PodOverlay.
+	RoleScheduling *RoleSchedulingPolicy `json:"roleScheduling,omitempty"`
+	// RolloutStrategy controls how a ReplicasetTemplate change (image, env,
+	// rolesToAssign) is rolled out across this Role's existing StatefulSets.
+	// Defaults to RollingUpdate with MaxUnavailable 1.
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// RolloutStrategyType selects how RoleReconciler updates a Role's StatefulSets once
+// their ReplicasetTemplate changes.
+type RolloutStrategyType string
+
+const (
+	// RollingUpdateRolloutStrategy updates every StatefulSet, highest-ordinal-first,
+	// honoring MaxUnavailable.
+	RollingUpdateRolloutStrategy RolloutStrategyType = "RollingUpdate"
+	// PartitionedRolloutStrategy only updates StatefulSets whose ordinal is >=
+	// Partition, so an operator can canary a template change on the
+	// highest-ordinal replicasets before widening Partition to roll it out further.
+	PartitionedRolloutStrategy RolloutStrategyType = "Partitioned"
+	// OnDeleteRolloutStrategy never updates a StatefulSet's pod template on its own;
+	// a change only takes effect once something else deletes the pod, same as the
+	// StatefulSet "OnDelete" update strategy it mirrors.
+	OnDeleteRolloutStrategy RolloutStrategyType = "OnDelete"
+)
+
+// RolloutStrategy is modeled on StatefulSet's own RollingUpdateStatefulSetStrategy
+// partitioning, applied one level up across a Role's StatefulSets instead of within a
+// single one.
+type RolloutStrategy struct {
+	// Type selects the rollout strategy. Defaults to RollingUpdate.
+	Type RolloutStrategyType `json:"type,omitempty"`
+	// MaxUnavailable bounds how many of this Role's StatefulSets RoleReconciler will
+	// have mid-update at once, measured by not-yet-Ready pods. May be an absolute
+	// number or a percentage of NumReplicasets. Only meaningful for RollingUpdate and
+	// Partitioned. Defaults to 1.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// Partition is the ordinal boundary for PartitionedRolloutStrategy: StatefulSets
+	// with ordinal below Partition are left on their current template revision.
+	// Ignored for RollingUpdate and OnDelete.
+	Partition *int32 `json:"partition,omitempty"`
+}
+
+// RoleSchedulingMode selects how RoleSchedulingPolicy's node affinity is enforced.
+type RoleSchedulingMode string
+
+const (
+	// RoleSchedulingRequired makes the affinity a hard scheduling requirement.
+	RoleSchedulingRequired RoleSchedulingMode = "Required"
+	// RoleSchedulingPreferred makes the affinity a soft scheduling preference.
+	RoleSchedulingPreferred RoleSchedulingMode = "Preferred"
+	// RoleSchedulingOff disables role-based node affinity and tolerations entirely.
+	RoleSchedulingOff RoleSchedulingMode = "Off"
+)
+
+// RoleSchedulingPolicy translates a Role's assigned roles (e.g. "router", "storage")
+// into node affinity and matching tolerations, so an operator can pin roles to nodes
+// labeled/tainted with <TaintKey>=<role> without hand-writing a PodOverlay.
+type RoleSchedulingPolicy struct {
+	// Mode is Required, Preferred, or Off. Defaults to Off when unset.
+	Mode RoleSchedulingMode `json:"mode,omitempty"`
+	// Weight is the preference weight, 1-100, used when Mode is Preferred. Defaults to 1.
+	Weight int32 `json:"weight,omitempty"`
+	// TaintKey is the node label and taint key roles are matched against. Defaults to
+	// tarantool.io/role.
+	TaintKey string `json:"taintKey,omitempty"`
+}
+
+// PodOverlay is merged into a Role's StatefulSet pod template at materialization time.
+// Env/volume/mount/container entries are appended to the ReplicasetTemplate's, while
+// NodeSelector, Tolerations, and Affinity replace the template's values when set.
+type PodOverlay struct {
+	// ExtraEnv is appended to the main (Tarantool) container's env, found by
+	// ReplicasetTemplate.MainContainerIndex.
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+	// ExtraEnvFrom is appended to the main container's envFrom.
+	ExtraEnvFrom []corev1.EnvFromSource `json:"extraEnvFrom,omitempty"`
+	// ExtraVolumes is appended to the pod spec's volumes.
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+	// ExtraVolumeMounts is appended to the main container's volumeMounts.
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+	// ExtraContainers is appended to the pod spec's containers as sidecars.
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+	// NodeSelector, if set, replaces the pod spec's nodeSelector.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations, if set, replaces the pod spec's tolerations.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity, if set, replaces the pod spec's affinity.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// ReplicasetDrainPhase is one stage of a replicaset's removal after a NumReplicasets
+// downscale, mirroring the phases ClusterReconciler's reconcileDraining/
+// reconcileScheduledDelete drive it through.
+type ReplicasetDrainPhase string
+
+const (
+	// ReplicasetDraining means vshard is still migrating buckets off the replicaset.
+	ReplicasetDraining ReplicasetDrainPhase = "Draining"
+	// ReplicasetExpelling means the replicaset has drained its buckets and is being
+	// expelled from the Cartridge topology before its StatefulSet is deleted.
+	ReplicasetExpelling ReplicasetDrainPhase = "Expelling"
+)
+
+// ReplicasetDrainStatus reports one replicaset's progress being removed after a
+// NumReplicasets downscale marked it for deletion.
+type ReplicasetDrainStatus struct {
+	// Name is the draining StatefulSet's name.
+	Name string `json:"name"`
+	// Phase is the replicaset's current removal phase.
+	Phase ReplicasetDrainPhase `json:"phase"`
+}
+
+// RoleStatus defines the observed state of Role
+type RoleStatus struct {
+	// ObservedGeneration is the most recent Role generation the controller has acted on.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions reports the status of each reconciliation stage the controller performs.
+	Conditions []ClusterCondition `json:"conditions,omitempty"`
+	// DrainingReplicasets reports every replicaset a NumReplicasets downscale has
+	// marked for removal that hasn't finished draining and being expelled yet, so
+	// `kubectl get role`/`kubectl describe role` shows a downscale's progress instead
+	// of only the Cluster's logs and conditions.
+	DrainingReplicasets []ReplicasetDrainStatus `json:"drainingReplicasets,omitempty"`
+	// Replicas is the number of StatefulSets RoleReconciler currently manages for this
+	// Role (the observed count, which may briefly differ from NumReplicasets while a
+	// scale or downscale is in progress).
+	Replicas int32 `json:"replicas,omitempty"`
+	// UpdatedReplicas is how many of those StatefulSets carry the current
+	// ReplicasetTemplate's revision, per RolloutStrategy.
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+	// AvailableReplicas is how many of those StatefulSets have a Ready pod.
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// Role is the Schema for the roles API
+type Role struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleSpec   `json:"spec,omitempty"`
+	Status RoleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RoleList contains a list of Role
+type RoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Role `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+}
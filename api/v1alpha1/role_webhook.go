@@ -0,0 +1,245 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers Role's defaulting and validating webhooks with mgr.
+func (r *Role) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookClient = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-tarantool-io-v1alpha1-role,mutating=true,failurePolicy=fail,sideEffects=None,groups=tarantool.io,resources=roles,verbs=create;update,versions=v1alpha1,name=mrole.kb.io,admissionReviewVersions=v1
+
+// Role satisfies admission.Defaulter/Validator via zz_generated.deepcopy.go's
+// DeepCopyObject; the assertions below don't compile without it.
+var _ webhook.Defaulter = &Role{}
+
+// Default implements webhook.Defaulter, filling in Spec.Weight, Spec.RolloutStrategy,
+// and Spec.RoleScheduling's documented defaults so a persisted Role always shows the
+// value the controller will actually use instead of leaving it implicit.
+func (r *Role) Default() {
+	if r.Spec.Weight == nil {
+		defaultWeight := 100
+		r.Spec.Weight = &defaultWeight
+	}
+
+	if r.Spec.RolloutStrategy != nil {
+		if r.Spec.RolloutStrategy.Type == "" {
+			r.Spec.RolloutStrategy.Type = RollingUpdateRolloutStrategy
+		}
+		if r.Spec.RolloutStrategy.MaxUnavailable == nil {
+			one := intstr.FromInt(1)
+			r.Spec.RolloutStrategy.MaxUnavailable = &one
+		}
+	}
+
+	if r.Spec.RoleScheduling != nil && r.Spec.RoleScheduling.Mode != RoleSchedulingOff && r.Spec.RoleScheduling.Mode != "" {
+		if r.Spec.RoleScheduling.TaintKey == "" {
+			r.Spec.RoleScheduling.TaintKey = "tarantool.io/role"
+		}
+		if r.Spec.RoleScheduling.Mode == RoleSchedulingPreferred && r.Spec.RoleScheduling.Weight == 0 {
+			r.Spec.RoleScheduling.Weight = 1
+		}
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-tarantool-io-v1alpha1-role,mutating=false,failurePolicy=fail,sideEffects=None,groups=tarantool.io,resources=roles,verbs=create;update,versions=v1alpha1,name=vrole.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Role{}
+
+// ValidateCreate implements webhook.Validator, rejecting a Role whose
+// Spec.NumReplicasets or Spec.Selector is unset -- RoleReconciler dereferences both
+// without a nil check.
+func (r *Role) ValidateCreate() error {
+	return r.validateSpec()
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (r *Role) ValidateUpdate(old runtime.Object) error {
+	return r.validateSpec()
+}
+
+// ValidateDelete implements webhook.Validator, rejecting deletion of a Role that still
+// owns a joined pod, so an operator expels pods explicitly (e.g. by scaling the Role
+// down) instead of the RoleReconciler losing track of them mid-expel.
+func (r *Role) ValidateDelete() error {
+	if webhookClient == nil || r.Spec.Selector == nil {
+		return nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(r.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("spec.selector: %w", err)
+	}
+
+	podList := &corev1.PodList{}
+	if err := webhookClient.List(context.Background(), podList, client.InNamespace(r.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("listing Pods for spec.selector: %w", err)
+	}
+
+	for _, pod := range podList.Items {
+		if pod.GetLabels()["tarantool.io/instance-state"] == "joined" {
+			return fmt.Errorf("role %q still owns joined pod %q; expel it before deleting the role", r.GetName(), pod.GetName())
+		}
+	}
+
+	return nil
+}
+
+// validateSpec rejects a nil or non-positive Spec.NumReplicasets and a nil Spec.Selector.
+func (r *Role) validateSpec() error {
+	if r.Spec.NumReplicasets == nil {
+		return fmt.Errorf("spec.numReplicasets is required")
+	}
+	if *r.Spec.NumReplicasets <= 0 {
+		return fmt.Errorf("spec.numReplicasets must be positive, got %d", *r.Spec.NumReplicasets)
+	}
+	if r.Spec.Selector == nil {
+		return fmt.Errorf("spec.selector is required")
+	}
+
+	if err := r.validateRolesConflict(); err != nil {
+		return err
+	}
+
+	return r.validateRolesAgainstCatalog()
+}
+
+// validateRolesConflict rejects a Role whose Spec.RolesToAssign disagrees with the
+// deprecated tarantool.io/rolesToAssign annotation/label already set on its matched
+// ReplicasetTemplate. Spec.RolesToAssign always wins at reconcile time (see
+// rolesToAssignValue in the role controller), so without this check the ReplicasetTemplate
+// value is silently shadowed instead of surfacing as a conflict the user should resolve.
+func (r *Role) validateRolesConflict() error {
+	if len(r.Spec.RolesToAssign) == 0 || webhookClient == nil {
+		return nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(r.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("spec.selector: %w", err)
+	}
+
+	templateList := &ReplicasetTemplateList{}
+	if err := webhookClient.List(context.Background(), templateList, client.InNamespace(r.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("listing ReplicasetTemplates for spec.selector: %w", err)
+	}
+	if len(templateList.Items) == 0 {
+		return nil
+	}
+
+	deprecated, ok := templateList.Items[0].ObjectMeta.Annotations["tarantool.io/rolesToAssign"]
+	if !ok {
+		deprecated, ok = templateList.Items[0].ObjectMeta.Labels["tarantool.io/rolesToAssign"]
+	}
+	if !ok {
+		return nil
+	}
+
+	encoded, err := json.Marshal(r.Spec.RolesToAssign)
+	if err != nil {
+		return fmt.Errorf("spec.rolesToAssign: %w", err)
+	}
+	if string(encoded) == deprecated {
+		return nil
+	}
+
+	return fmt.Errorf("spec.rolesToAssign %s conflicts with ReplicasetTemplate %q's tarantool.io/rolesToAssign (%s)",
+		encoded, templateList.Items[0].GetName(), deprecated)
+}
+
+// validateRolesAgainstCatalog rejects a RolesToAssign entry the matched
+// ReplicasetTemplate's image doesn't advertise in a RoleCatalog. It's a no-op --
+// skipped rather than failing closed -- whenever RolesToAssign is unset, webhookClient
+// is nil (e.g. unit tests), the Selector matches no ReplicasetTemplate yet, or no
+// RoleCatalog describes that image, since RoleCatalog coverage is opt-in.
+func (r *Role) validateRolesAgainstCatalog() error {
+	if len(r.Spec.RolesToAssign) == 0 || webhookClient == nil {
+		return nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(r.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("spec.selector: %w", err)
+	}
+
+	templateList := &ReplicasetTemplateList{}
+	if err := webhookClient.List(context.Background(), templateList, client.InNamespace(r.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("listing ReplicasetTemplates for spec.selector: %w", err)
+	}
+	if len(templateList.Items) == 0 || templateList.Items[0].Spec == nil || len(templateList.Items[0].Spec.Template.Spec.Containers) == 0 {
+		return nil
+	}
+	image := templateList.Items[0].Spec.Template.Spec.Containers[0].Image
+
+	catalogList := &RoleCatalogList{}
+	if err := webhookClient.List(context.Background(), catalogList, client.InNamespace(r.GetNamespace())); err != nil {
+		return fmt.Errorf("listing RoleCatalogs: %w", err)
+	}
+
+	var allowed []string
+	for _, catalog := range catalogList.Items {
+		if catalog.Spec.Image == image {
+			allowed = catalog.Spec.Roles
+			break
+		}
+	}
+	if allowed == nil {
+		return nil
+	}
+
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, role := range allowed {
+		allowedSet[role] = true
+	}
+
+	for _, role := range r.Spec.RolesToAssign {
+		if !allowedSet[role] {
+			return fmt.Errorf("spec.rolesToAssign: %q is not advertised by RoleCatalog for image %q", role, image)
+		}
+	}
+
+	return nil
+}
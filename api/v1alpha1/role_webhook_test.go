@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Role validating webhook", func() {
+	AfterEach(func() {
+		webhookClient = nil
+	})
+
+	Describe("Default", func() {
+		It("fills in the documented defaults", func() {
+			r := &Role{Spec: RoleSpec{RolloutStrategy: &RolloutStrategy{}}}
+			r.Default()
+
+			Expect(*r.Spec.Weight).To(Equal(100))
+			Expect(r.Spec.RolloutStrategy.Type).To(Equal(RollingUpdateRolloutStrategy))
+			Expect(*r.Spec.RolloutStrategy.MaxUnavailable).To(Equal(intstr.FromInt(1)))
+		})
+	})
+
+	Describe("ValidateCreate", func() {
+		DescribeTable("rejects invalid Roles",
+			func(r *Role, wantErr string) {
+				err := r.ValidateCreate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(wantErr))
+			},
+			Entry("missing spec.numReplicasets",
+				&Role{ObjectMeta: metav1.ObjectMeta{Name: "no-num", Namespace: "default"}},
+				"spec.numReplicasets is required",
+			),
+			Entry("non-positive spec.numReplicasets",
+				func() *Role {
+					n := int32(0)
+					return &Role{ObjectMeta: metav1.ObjectMeta{Name: "zero-num", Namespace: "default"}, Spec: RoleSpec{NumReplicasets: &n}}
+				}(),
+				"spec.numReplicasets must be positive",
+			),
+			Entry("missing spec.selector",
+				func() *Role {
+					n := int32(1)
+					return &Role{ObjectMeta: metav1.ObjectMeta{Name: "no-selector", Namespace: "default"}, Spec: RoleSpec{NumReplicasets: &n}}
+				}(),
+				"spec.selector is required",
+			),
+		)
+
+		It("rejects spec.rolesToAssign that conflicts with the matched ReplicasetTemplate's annotation", func() {
+			n := int32(1)
+			rsTemplate := &ReplicasetTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "rs-template",
+					Namespace:   "default",
+					Labels:      map[string]string{"tarantool.io/replicaset-template": "rs-template"},
+					Annotations: map[string]string{"tarantool.io/rolesToAssign": `["router"]`},
+				},
+			}
+			webhookClient = fake.NewClientBuilder().WithScheme(newClusterWebhookScheme()).WithObjects(rsTemplate).Build()
+
+			r := &Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "conflicting-role", Namespace: "default"},
+				Spec: RoleSpec{
+					NumReplicasets: &n,
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"tarantool.io/replicaset-template": "rs-template"}},
+					RolesToAssign:  []string{"storage"},
+				},
+			}
+
+			err := r.ValidateCreate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("conflicts with ReplicasetTemplate"))
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		It("rejects deleting a Role that still owns a joined Pod", func() {
+			n := int32(1)
+			r := &Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "role-with-joined-pod", Namespace: "default"},
+				Spec: RoleSpec{
+					NumReplicasets: &n,
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"tarantool.io/role": "role-with-joined-pod"}},
+				},
+			}
+			joinedPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "joined-pod",
+					Namespace: "default",
+					Labels: map[string]string{
+						"tarantool.io/role":           "role-with-joined-pod",
+						"tarantool.io/instance-state": "joined",
+					},
+				},
+			}
+			webhookClient = fake.NewClientBuilder().WithScheme(newClusterWebhookScheme()).WithObjects(joinedPod).Build()
+
+			err := r.ValidateDelete()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("still owns joined pod"))
+		})
+
+		It("accepts deleting a Role with no joined Pods", func() {
+			n := int32(1)
+			r := &Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "role-without-pods", Namespace: "default"},
+				Spec: RoleSpec{
+					NumReplicasets: &n,
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"tarantool.io/role": "role-without-pods"}},
+				},
+			}
+			webhookClient = fake.NewClientBuilder().WithScheme(newClusterWebhookScheme()).Build()
+
+			Expect(r.ValidateDelete()).NotTo(HaveOccurred())
+		})
+	})
+})
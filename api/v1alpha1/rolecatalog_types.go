@@ -0,0 +1,73 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+//
+// RoleCatalog advertises the Cartridge roles a given container image supports, so the
+// Role admission webhook can reject a RolesToAssign entry the image was never built to
+// handle, instead of that surfacing later as a cryptic join_server failure.
+
+// RoleCatalogSpec defines the desired state of RoleCatalog
+type RoleCatalogSpec struct {
+	// Image is the container image this catalog describes, matched against a Role's
+	// ReplicasetTemplate's first container image.
+	Image string `json:"image"`
+	// Roles lists every Cartridge role Image supports assigning via RolesToAssign.
+	// +kubebuilder:validation:MinItems=1
+	Roles []string `json:"roles"`
+}
+
+//+kubebuilder:object:root=true
+
+// RoleCatalog is the Schema for the rolecatalogs API
+type RoleCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RoleCatalogSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RoleCatalogList contains a list of RoleCatalog
+type RoleCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoleCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RoleCatalog{}, &RoleCatalogList{})
+}
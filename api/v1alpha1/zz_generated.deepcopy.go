@@ -0,0 +1,1015 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+// Code generated by hand to stand in for controller-gen's object deepcopy
+// generator, which this repo has no Makefile/PROJECT wired up to invoke. Keep
+// it in sync with the types in this package by hand until that's fixed: every
+// struct reachable from a runtime.Object needs a DeepCopy/DeepCopyInto pair,
+// and every runtime.Object needs DeepCopyObject.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cluster) DeepCopyInto(out *Cluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new Cluster.
+func (in *Cluster) DeepCopy() *Cluster {
+	if in == nil {
+		return nil
+	}
+	out := new(Cluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Cluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterList) DeepCopyInto(out *ClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]Cluster, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterList.
+func (in *ClusterList) DeepCopy() *ClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+	if in.Selector != nil {
+		out.Selector = in.Selector.DeepCopy()
+	}
+	if in.Auth != nil {
+		out.Auth = in.Auth.DeepCopy()
+	}
+	if in.LeaderLeaseDuration != nil {
+		out.LeaderLeaseDuration = new(metav1.Duration)
+		*out.LeaderLeaseDuration = *in.LeaderLeaseDuration
+	}
+	if in.UpgradeStrategy != nil {
+		out.UpgradeStrategy = in.UpgradeStrategy.DeepCopy()
+	}
+	if in.Maintenance != nil {
+		l := make([]MaintenanceWindow, len(in.Maintenance))
+		copy(l, in.Maintenance)
+		out.Maintenance = l
+	}
+	if in.ExpelTimeout != nil {
+		out.ExpelTimeout = new(metav1.Duration)
+		*out.ExpelTimeout = *in.ExpelTimeout
+	}
+	if in.JoinTTL != nil {
+		out.JoinTTL = new(metav1.Duration)
+		*out.JoinTTL = *in.JoinTTL
+	}
+	if in.Failover != nil {
+		out.Failover = in.Failover.DeepCopy()
+	}
+	if in.Config != nil {
+		m := make(map[string]string, len(in.Config))
+		for k, v := range in.Config {
+			m[k] = v
+		}
+		out.Config = m
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverSpec) DeepCopyInto(out *FailoverSpec) {
+	*out = *in
+	if in.ETCD2 != nil {
+		out.ETCD2 = in.ETCD2.DeepCopy()
+	}
+	if in.Stateboard != nil {
+		out.Stateboard = in.Stateboard.DeepCopy()
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new FailoverSpec.
+func (in *FailoverSpec) DeepCopy() *FailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCD2FailoverParams) DeepCopyInto(out *ETCD2FailoverParams) {
+	*out = *in
+	if in.Endpoints != nil {
+		l := make([]string, len(in.Endpoints))
+		copy(l, in.Endpoints)
+		out.Endpoints = l
+	}
+	if in.LockDelay != nil {
+		out.LockDelay = new(metav1.Duration)
+		*out.LockDelay = *in.LockDelay
+	}
+	if in.PasswordSecretRef != nil {
+		out.PasswordSecretRef = new(corev1.LocalObjectReference)
+		*out.PasswordSecretRef = *in.PasswordSecretRef
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ETCD2FailoverParams.
+func (in *ETCD2FailoverParams) DeepCopy() *ETCD2FailoverParams {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCD2FailoverParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateboardFailoverParams) DeepCopyInto(out *StateboardFailoverParams) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		out.PasswordSecretRef = new(corev1.LocalObjectReference)
+		*out.PasswordSecretRef = *in.PasswordSecretRef
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new StateboardFailoverParams.
+func (in *StateboardFailoverParams) DeepCopy() *StateboardFailoverParams {
+	if in == nil {
+		return nil
+	}
+	out := new(StateboardFailoverParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStrategy) DeepCopyInto(out *UpgradeStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		out.MaxUnavailable = new(int32)
+		*out.MaxUnavailable = *in.MaxUnavailable
+	}
+	if in.DrainTimeout != nil {
+		out.DrainTimeout = new(metav1.Duration)
+		*out.DrainTimeout = *in.DrainTimeout
+	}
+	if in.BucketRebalanceTimeout != nil {
+		out.BucketRebalanceTimeout = new(metav1.Duration)
+		*out.BucketRebalanceTimeout = *in.BucketRebalanceTimeout
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new UpgradeStrategy.
+func (in *UpgradeStrategy) DeepCopy() *UpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAuth) DeepCopyInto(out *ClusterAuth) {
+	*out = *in
+	if in.SecretRef != nil {
+		out.SecretRef = new(corev1.LocalObjectReference)
+		*out.SecretRef = *in.SecretRef
+	}
+	if in.TLS != nil {
+		out.TLS = in.TLS.DeepCopy()
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterAuth.
+func (in *ClusterAuth) DeepCopy() *ClusterAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAuthTLS) DeepCopyInto(out *ClusterAuthTLS) {
+	*out = *in
+	if in.CASecretRef != nil {
+		out.CASecretRef = new(corev1.LocalObjectReference)
+		*out.CASecretRef = *in.CASecretRef
+	}
+	if in.ClientCertSecretRef != nil {
+		out.ClientCertSecretRef = new(corev1.LocalObjectReference)
+		*out.ClientCertSecretRef = *in.ClientCertSecretRef
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterAuthTLS.
+func (in *ClusterAuthTLS) DeepCopy() *ClusterAuthTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAuthTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterCondition.
+func (in *ClusterCondition) DeepCopy() *ClusterCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		l := make([]ClusterCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&l[i])
+		}
+		out.Conditions = l
+	}
+	if in.UpgradeProgress != nil {
+		out.UpgradeProgress = new(UpgradeProgress)
+		*out.UpgradeProgress = *in.UpgradeProgress
+	}
+	if in.Leader != nil {
+		out.Leader = in.Leader.DeepCopy()
+	}
+	if in.NextScheduledRuns != nil {
+		m := make(map[MaintenanceOperation]metav1.Time, len(in.NextScheduledRuns))
+		for k, v := range in.NextScheduledRuns {
+			m[k] = *v.DeepCopy()
+		}
+		out.NextScheduledRuns = m
+	}
+	if in.Failover != nil {
+		out.Failover = new(FailoverStatus)
+		*out.Failover = *in.Failover
+	}
+	if in.Federation != nil {
+		out.Federation = new(FederationStatus)
+		*out.Federation = *in.Federation
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLeader) DeepCopyInto(out *ClusterLeader) {
+	*out = *in
+	if in.Since != nil {
+		out.Since = in.Since.DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		out.ExpiresAt = in.ExpiresAt.DeepCopy()
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterLeader.
+func (in *ClusterLeader) DeepCopy() *ClusterLeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new FederationStatus.
+func (in *FederationStatus) DeepCopy() *FederationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FederationStatus)
+	*out = *in
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
+	if in == nil {
+		return nil
+	}
+	out := new(Role)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Role) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleList) DeepCopyInto(out *RoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]Role, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RoleList.
+func (in *RoleList) DeepCopy() *RoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+	*out = *in
+	if in.Selector != nil {
+		out.Selector = in.Selector.DeepCopy()
+	}
+	if in.RolesToAssign != nil {
+		l := make([]string, len(in.RolesToAssign))
+		copy(l, in.RolesToAssign)
+		out.RolesToAssign = l
+	}
+	if in.VshardGroup != nil {
+		out.VshardGroup = new(string)
+		*out.VshardGroup = *in.VshardGroup
+	}
+	if in.Weight != nil {
+		out.Weight = new(int)
+		*out.Weight = *in.Weight
+	}
+	if in.PodOverlay != nil {
+		out.PodOverlay = in.PodOverlay.DeepCopy()
+	}
+	if in.RoleScheduling != nil {
+		out.RoleScheduling = new(RoleSchedulingPolicy)
+		*out.RoleScheduling = *in.RoleScheduling
+	}
+	if in.RolloutStrategy != nil {
+		out.RolloutStrategy = in.RolloutStrategy.DeepCopy()
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		out.MaxUnavailable = new(intstr.IntOrString)
+		*out.MaxUnavailable = *in.MaxUnavailable
+	}
+	if in.Partition != nil {
+		out.Partition = new(int32)
+		*out.Partition = *in.Partition
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodOverlay) DeepCopyInto(out *PodOverlay) {
+	*out = *in
+	if in.ExtraEnv != nil {
+		l := make([]corev1.EnvVar, len(in.ExtraEnv))
+		for i := range in.ExtraEnv {
+			in.ExtraEnv[i].DeepCopyInto(&l[i])
+		}
+		out.ExtraEnv = l
+	}
+	if in.ExtraEnvFrom != nil {
+		l := make([]corev1.EnvFromSource, len(in.ExtraEnvFrom))
+		for i := range in.ExtraEnvFrom {
+			in.ExtraEnvFrom[i].DeepCopyInto(&l[i])
+		}
+		out.ExtraEnvFrom = l
+	}
+	if in.ExtraVolumes != nil {
+		l := make([]corev1.Volume, len(in.ExtraVolumes))
+		for i := range in.ExtraVolumes {
+			in.ExtraVolumes[i].DeepCopyInto(&l[i])
+		}
+		out.ExtraVolumes = l
+	}
+	if in.ExtraVolumeMounts != nil {
+		l := make([]corev1.VolumeMount, len(in.ExtraVolumeMounts))
+		for i := range in.ExtraVolumeMounts {
+			in.ExtraVolumeMounts[i].DeepCopyInto(&l[i])
+		}
+		out.ExtraVolumeMounts = l
+	}
+	if in.ExtraContainers != nil {
+		l := make([]corev1.Container, len(in.ExtraContainers))
+		for i := range in.ExtraContainers {
+			in.ExtraContainers[i].DeepCopyInto(&l[i])
+		}
+		out.ExtraContainers = l
+	}
+	if in.NodeSelector != nil {
+		m := make(map[string]string, len(in.NodeSelector))
+		for k, v := range in.NodeSelector {
+			m[k] = v
+		}
+		out.NodeSelector = m
+	}
+	if in.Tolerations != nil {
+		l := make([]corev1.Toleration, len(in.Tolerations))
+		for i := range in.Tolerations {
+			in.Tolerations[i].DeepCopyInto(&l[i])
+		}
+		out.Tolerations = l
+	}
+	if in.Affinity != nil {
+		out.Affinity = in.Affinity.DeepCopy()
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new PodOverlay.
+func (in *PodOverlay) DeepCopy() *PodOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(PodOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasetDrainStatus) DeepCopyInto(out *ReplicasetDrainStatus) {
+	*out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ReplicasetDrainStatus.
+func (in *ReplicasetDrainStatus) DeepCopy() *ReplicasetDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasetDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		l := make([]ClusterCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&l[i])
+		}
+		out.Conditions = l
+	}
+	if in.DrainingReplicasets != nil {
+		l := make([]ReplicasetDrainStatus, len(in.DrainingReplicasets))
+		copy(l, in.DrainingReplicasets)
+		out.DrainingReplicasets = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RoleStatus.
+func (in *RoleStatus) DeepCopy() *RoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasetTemplate) DeepCopyInto(out *ReplicasetTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Spec != nil {
+		out.Spec = in.Spec.DeepCopy()
+	}
+	out.Status = in.Status
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ReplicasetTemplate.
+func (in *ReplicasetTemplate) DeepCopy() *ReplicasetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicasetTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasetTemplateList) DeepCopyInto(out *ReplicasetTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]ReplicasetTemplate, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ReplicasetTemplateList.
+func (in *ReplicasetTemplateList) DeepCopy() *ReplicasetTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasetTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicasetTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ReplicasetTemplateStatus.
+func (in *ReplicasetTemplateStatus) DeepCopy() *ReplicasetTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasetTemplateStatus)
+	*out = *in
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedCluster) DeepCopyInto(out *FederatedCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new FederatedCluster.
+func (in *FederatedCluster) DeepCopy() *FederatedCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FederatedCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedClusterList) DeepCopyInto(out *FederatedClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]FederatedCluster, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new FederatedClusterList.
+func (in *FederatedClusterList) DeepCopy() *FederatedClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FederatedClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedClusterSpec) DeepCopyInto(out *FederatedClusterSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Placements != nil {
+		l := make([]ClusterPlacement, len(in.Placements))
+		for i := range in.Placements {
+			in.Placements[i].DeepCopyInto(&l[i])
+		}
+		out.Placements = l
+	}
+	if in.Topology != nil {
+		m := make(map[string][]string, len(in.Topology))
+		for k, v := range in.Topology {
+			var l []string
+			if v != nil {
+				l = make([]string, len(v))
+				copy(l, v)
+			}
+			m[k] = l
+		}
+		out.Topology = m
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new FederatedClusterSpec.
+func (in *FederatedClusterSpec) DeepCopy() *FederatedClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlacement) DeepCopyInto(out *ClusterPlacement) {
+	*out = *in
+	if in.Override != nil {
+		out.Override = in.Override.DeepCopy()
+	}
+	if in.KubeconfigSecretRef != nil {
+		out.KubeconfigSecretRef = new(corev1.SecretReference)
+		*out.KubeconfigSecretRef = *in.KubeconfigSecretRef
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterPlacement.
+func (in *ClusterPlacement) DeepCopy() *ClusterPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlacementOverride) DeepCopyInto(out *ClusterPlacementOverride) {
+	*out = *in
+	if in.NumReplicasets != nil {
+		out.NumReplicasets = new(int32)
+		*out.NumReplicasets = *in.NumReplicasets
+	}
+	if in.Weight != nil {
+		out.Weight = new(int32)
+		*out.Weight = *in.Weight
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterPlacementOverride.
+func (in *ClusterPlacementOverride) DeepCopy() *ClusterPlacementOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlacementOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedClusterStatus) DeepCopyInto(out *FederatedClusterStatus) {
+	*out = *in
+	if in.PlacementStatuses != nil {
+		m := make(map[string][]ClusterCondition, len(in.PlacementStatuses))
+		for k, v := range in.PlacementStatuses {
+			var l []ClusterCondition
+			if v != nil {
+				l = make([]ClusterCondition, len(v))
+				for i := range v {
+					v[i].DeepCopyInto(&l[i])
+				}
+			}
+			m[k] = l
+		}
+		out.PlacementStatuses = m
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new FederatedClusterStatus.
+func (in *FederatedClusterStatus) DeepCopy() *FederatedClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightCheck) DeepCopyInto(out *PreflightCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new PreflightCheck.
+func (in *PreflightCheck) DeepCopy() *PreflightCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreflightCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightCheckList) DeepCopyInto(out *PreflightCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]PreflightCheck, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new PreflightCheckList.
+func (in *PreflightCheckList) DeepCopy() *PreflightCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreflightCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightCheckStatus) DeepCopyInto(out *PreflightCheckStatus) {
+	*out = *in
+	if in.Results != nil {
+		l := make([]PreflightCheckResult, len(in.Results))
+		copy(l, in.Results)
+		out.Results = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new PreflightCheckStatus.
+func (in *PreflightCheckStatus) DeepCopy() *PreflightCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleCatalog) DeepCopyInto(out *RoleCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RoleCatalog.
+func (in *RoleCatalog) DeepCopy() *RoleCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleCatalogList) DeepCopyInto(out *RoleCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		l := make([]RoleCatalog, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&l[i])
+		}
+		out.Items = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RoleCatalogList.
+func (in *RoleCatalogList) DeepCopy() *RoleCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleCatalogSpec) DeepCopyInto(out *RoleCatalogSpec) {
+	*out = *in
+	if in.Roles != nil {
+		l := make([]string, len(in.Roles))
+		copy(l, in.Roles)
+		out.Roles = l
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RoleCatalogSpec.
+func (in *RoleCatalogSpec) DeepCopy() *RoleCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -30,17 +30,25 @@ package controllers
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -50,6 +58,9 @@ import (
 
 	"github.com/google/uuid"
 	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	"github.com/tarantool/tarantool-operator/controllers/kubeutil"
+	"github.com/tarantool/tarantool-operator/controllers/leaderelection"
+	"github.com/tarantool/tarantool-operator/controllers/leaderlease"
 	"github.com/tarantool/tarantool-operator/controllers/tarantool"
 	"github.com/tarantool/tarantool-operator/controllers/topology"
 	"github.com/tarantool/tarantool-operator/controllers/utils"
@@ -61,21 +72,67 @@ var space = uuid.MustParse("73692FF6-EB42-46C2-92B6-65C45191368D")
 type ClusterReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// RoleIndex, when set, resolves leader-election candidates from a shared
+	// informer's local cache instead of Get-ing each Endpoint address's Pod, so
+	// clusters with hundreds of replicasets don't pay a List/Get per reconcile.
+	RoleIndex *topology.RoleIndex
+	// Recorder emits Kubernetes events for reconcile-stage transitions that are worth
+	// surfacing on `kubectl describe cluster`/`kubectl get events`, not just the logs.
+	// Set by SetupWithManager.
+	Recorder record.EventRecorder
+
+	// WatchNamespace, if set, restricts the Pod watch ManagedPodPredicate filters to a
+	// single namespace, letting a per-tenant operator instance share a Kubernetes
+	// cluster with others without reconciling their Pods. Empty means every namespace.
+	WatchNamespace string
+	// ClusterLabelSelector, if set, further restricts the Pod watch to Pods matching
+	// it, for operator instances sharded by something other than namespace (e.g. one
+	// instance per cluster-label-selector shard). Nil means every Pod.
+	ClusterLabelSelector labels.Selector
 }
 
-// Checking for a leader in the cluster Endpoint annotation
-func IsLeaderExists(ep *corev1.Endpoints) bool {
-	leader, ok := ep.Annotations["tarantool.io/leader"]
-	if !ok || leader == "" {
-		return false
+// defaultLeaderLeaseDuration is used when a Cluster doesn't set LeaderLeaseDuration.
+const defaultLeaderLeaseDuration = 15 * time.Second
+
+// leaderElectionPort is the port leader candidates accept admin API requests on,
+// unless overridden per Pod by adminPortAnnotation.
+const leaderElectionPort = 8081
+
+// adminPortAnnotation overrides leaderElectionPort on a single Pod, for clusters whose
+// Cartridge instances don't listen for admin API requests on the conventional port.
+const adminPortAnnotation = "tarantool.io/admin-port"
+
+// adminPortForPod returns the port a leader candidate should accept admin API requests
+// on: pod's adminPortAnnotation if set and a valid positive port, leaderElectionPort
+// otherwise. pod may be nil, e.g. an Endpoints-sourced candidate whose TargetRef
+// couldn't be resolved to a Pod.
+func adminPortForPod(pod *corev1.Pod) int {
+	if pod == nil {
+		return leaderElectionPort
 	}
 
-	for _, addr := range ep.Subsets[0].Addresses {
-		if leader == fmt.Sprintf("%s:%s", addr.IP, "8081") {
-			return true
-		}
+	raw, ok := pod.GetAnnotations()[adminPortAnnotation]
+	if !ok {
+		return leaderElectionPort
 	}
-	return false
+
+	port, err := strconv.Atoi(raw)
+	if err != nil || port <= 0 {
+		return leaderElectionPort
+	}
+
+	return port
+}
+
+// endpointSliceServiceLabel is the well-known label an EndpointSlice carries naming
+// the Service (here, the cluster-wide headless Service) it belongs to.
+const endpointSliceServiceLabel = "kubernetes.io/service-name"
+
+// IsLeaderExists reports whether a Cluster's leader Lease currently has a holder whose
+// lease hasn't expired. Replaces the old Endpoints-annotation scan, which couldn't tell
+// an operator restart from a genuinely missing leader and raced across reconciles.
+func IsLeaderExists(lease *coordinationv1.Lease) bool {
+	return leaderlease.IsHeld(lease, time.Now())
 }
 
 // HasInstanceUUID .
@@ -98,9 +155,435 @@ func SetInstanceUUID(o *corev1.Pod) *corev1.Pod {
 	labels["tarantool.io/instance-uuid"] = instanceUUID.String()
 
 	o.SetLabels(labels)
+
+	annotations := o.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["tarantool.io/join-started-at"] = time.Now().Format(time.RFC3339)
+	o.SetAnnotations(annotations)
+
 	return o
 }
 
+// defaultJoinTTL is how long a pod may hold a tarantool.io/instance-uuid label without
+// joining before it's considered to have failed to join, per tarantool.FailedToJoin.
+const defaultJoinTTL = 15 * time.Minute
+
+// remediateFailedJoin handles a pod that's exceeded its join TTL without joining the
+// cluster: it stamps the pod's join-deadline-exceeded label and JoinFailed condition,
+// emits a Warning event, records a JoinFailed condition on cluster, and -- if
+// cluster.Spec.AutoRemediate is set -- deletes the pod so its StatefulSet recreates it
+// with a fresh identity instead of it wedging this Cluster's reconcile forever.
+func (r *ClusterReconciler) remediateFailedJoin(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, pod *corev1.Pod) error {
+	reqLogger := log.FromContext(ctx)
+
+	tarantool.MarkJoinFailed(pod)
+	r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "JoinFailed", "pod %s did not join within its join TTL", pod.GetName())
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.JoinFailed, tarantooliov1alpha1.ConditionTrue, "JoinTTLExceeded", fmt.Sprintf("pod %s did not join within its join TTL", pod.GetName())); err != nil {
+		reqLogger.Error(err, "failed to record JoinFailed condition")
+	}
+
+	if !cluster.Spec.AutoRemediate {
+		return r.Update(ctx, pod)
+	}
+
+	if err := r.Update(ctx, pod); err != nil {
+		return err
+	}
+
+	reqLogger.Info("deleting pod that exceeded its join TTL", "Pod.Name", pod.GetName())
+	if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// setCondition records the status of a single reconciliation stage on cluster and
+// persists it, so a stuck or failing stage is visible on the object instead of only
+// in the logs.
+func (r *ClusterReconciler) setCondition(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, condType tarantooliov1alpha1.ClusterConditionType, status tarantooliov1alpha1.ConditionStatus, reason, message string) error {
+	cluster.Status.ObservedGeneration = cluster.GetGeneration()
+	cluster.Status.Conditions = tarantooliov1alpha1.SetCondition(cluster.Status.Conditions, tarantooliov1alpha1.ClusterCondition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	return r.Status().Update(ctx, cluster)
+}
+
+// podAddress returns the address an Endpoints/EndpointSlice would publish for pod:
+// the first of its (possibly dual-stack) PodIPs, falling back to the single-family
+// PodIP for pods that only ever set that field.
+func podAddress(pod *corev1.Pod) string {
+	if len(pod.Status.PodIPs) > 0 {
+		return pod.Status.PodIPs[0].IP
+	}
+	return pod.Status.PodIP
+}
+
+// buildLeaderCandidates resolves leader-election candidates for cluster, preferring
+// (in order): r.RoleIndex's local cache, the cluster's EndpointSlices, and finally
+// ep itself, for clusters whose EndpointSlices haven't been created yet. Addresses are
+// normalized through leaderlease.FormatHolderAddress so IPv6 candidates come out
+// bracketed, matching what's stored in the leader Lease.
+func (r *ClusterReconciler) buildLeaderCandidates(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, ep *corev1.Endpoints) []leaderelection.Candidate {
+	if r.RoleIndex != nil {
+		pods := r.RoleIndex.LeaderCandidates(cluster.GetName())
+		candidates := make([]leaderelection.Candidate, 0, len(pods))
+		for _, pod := range pods {
+			ip := podAddress(pod)
+			if ip == "" {
+				continue
+			}
+			candidates = append(candidates, leaderelection.Candidate{
+				Address: leaderlease.FormatHolderAddress(ip, adminPortForPod(pod)),
+				Pod:     pod,
+			})
+		}
+		return candidates
+	}
+
+	if candidates := r.leaderCandidatesFromEndpointSlices(ctx, cluster); candidates != nil {
+		return candidates
+	}
+
+	candidates := make([]leaderelection.Candidate, 0, len(ep.Subsets[0].Addresses))
+	for _, addr := range ep.Subsets[0].Addresses {
+		var pod *corev1.Pod
+		if addr.TargetRef != nil {
+			p := &corev1.Pod{}
+			podName := types.NamespacedName{Namespace: addr.TargetRef.Namespace, Name: addr.TargetRef.Name}
+			if err := r.Get(ctx, podName, p); err == nil {
+				pod = p
+			}
+		}
+
+		candidates = append(candidates, leaderelection.Candidate{
+			Address: leaderlease.FormatHolderAddress(addr.IP, adminPortForPod(pod)),
+			Pod:     pod,
+		})
+	}
+	return candidates
+}
+
+// leaderCandidatesFromEndpointSlices resolves leader-election candidates from
+// cluster's discovery.k8s.io/v1 EndpointSlices, which (unlike Endpoints) carry
+// separate slices per IP family, so a dual-stack cluster's IPv6 addresses aren't
+// dropped. It returns nil if cluster has no EndpointSlices yet, so the caller can fall
+// back to its legacy Endpoints object.
+func (r *ClusterReconciler) leaderCandidatesFromEndpointSlices(ctx context.Context, cluster *tarantooliov1alpha1.Cluster) []leaderelection.Candidate {
+	sliceList := &discoveryv1.EndpointSliceList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(cluster.GetNamespace()),
+		client.MatchingLabels{endpointSliceServiceLabel: cluster.GetName()},
+	}
+	if err := r.List(ctx, sliceList, listOpts...); err != nil || len(sliceList.Items) == 0 {
+		return nil
+	}
+
+	var candidates []leaderelection.Candidate
+	for _, slice := range sliceList.Items {
+		for _, endpoint := range slice.Endpoints {
+			if len(endpoint.Addresses) == 0 {
+				continue
+			}
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+
+			var pod *corev1.Pod
+			if endpoint.TargetRef != nil {
+				p := &corev1.Pod{}
+				podName := types.NamespacedName{Namespace: endpoint.TargetRef.Namespace, Name: endpoint.TargetRef.Name}
+				if err := r.Get(ctx, podName, p); err == nil {
+					pod = p
+				}
+			}
+
+			candidates = append(candidates, leaderelection.Candidate{
+				Address: leaderlease.FormatHolderAddress(endpoint.Addresses[0], adminPortForPod(pod)),
+				Pod:     pod,
+			})
+		}
+	}
+	return candidates
+}
+
+// buildTopologyOptions translates Cluster.Spec.Auth into topology functional options,
+// fetching whatever Secrets it references, so topologyClient calls authenticate to
+// Cartridge's admin API the same way the cluster was configured to expect.
+func (r *ClusterReconciler) buildTopologyOptions(ctx context.Context, cluster *tarantooliov1alpha1.Cluster) ([]topology.Option, error) {
+	auth := cluster.Spec.Auth
+	if auth == nil {
+		return nil, nil
+	}
+
+	var opts []topology.Option
+
+	if auth.SecretRef != nil {
+		secret := &corev1.Secret{}
+		name := types.NamespacedName{Namespace: cluster.GetNamespace(), Name: auth.SecretRef.Name}
+		if err := r.Get(ctx, name, secret); err != nil {
+			return nil, fmt.Errorf("getting auth secret %s: %w", auth.SecretRef.Name, err)
+		}
+
+		if token, ok := secret.Data["token"]; ok {
+			opts = append(opts, topology.WithBearerToken(string(token)))
+		} else {
+			opts = append(opts, topology.WithBasicAuth(string(secret.Data["username"]), string(secret.Data["password"])))
+		}
+	}
+
+	if auth.TLS != nil {
+		tlsConfig := &tls.Config{InsecureSkipVerify: auth.TLS.InsecureSkipVerify}
+
+		if auth.TLS.CASecretRef != nil {
+			caSecret := &corev1.Secret{}
+			name := types.NamespacedName{Namespace: cluster.GetNamespace(), Name: auth.TLS.CASecretRef.Name}
+			if err := r.Get(ctx, name, caSecret); err != nil {
+				return nil, fmt.Errorf("getting CA secret %s: %w", auth.TLS.CASecretRef.Name, err)
+			}
+
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caSecret.Data["ca.crt"]) {
+				return nil, fmt.Errorf("no certificates found in secret %s key ca.crt", auth.TLS.CASecretRef.Name)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if auth.TLS.ClientCertSecretRef != nil {
+			certSecret := &corev1.Secret{}
+			name := types.NamespacedName{Namespace: cluster.GetNamespace(), Name: auth.TLS.ClientCertSecretRef.Name}
+			if err := r.Get(ctx, name, certSecret); err != nil {
+				return nil, fmt.Errorf("getting client cert secret %s: %w", auth.TLS.ClientCertSecretRef.Name, err)
+			}
+
+			cert, err := tls.X509KeyPair(certSecret.Data["tls.crt"], certSecret.Data["tls.key"])
+			if err != nil {
+				return nil, fmt.Errorf("parsing client cert secret %s: %w", auth.TLS.ClientCertSecretRef.Name, err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		opts = append(opts, topology.WithTLSConfig(tlsConfig))
+	}
+
+	return opts, nil
+}
+
+// stateboardPort is the port a managed stateboard StatefulSet listens on and
+// advertises, matching Cartridge stateboard's own default.
+const stateboardPort = 4401
+
+// reconcileStateboard ensures a single-replica StatefulSet and headless Service running
+// Cartridge's stateboard process exist for cluster, and returns the advertise URI
+// instances should use to reach it. Only called when FailoverSpec.Stateboard.Managed is
+// set, so a Cluster pointing at an externally-run stateboard sees no extra objects.
+func (r *ClusterReconciler) reconcileStateboard(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, params *tarantooliov1alpha1.StateboardFailoverParams, password string) (string, error) {
+	name := cluster.GetName() + "-stateboard"
+	labels := map[string]string{"tarantool.io/stateboard": cluster.GetName()}
+
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: name}, svc); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+
+		svc.Name = name
+		svc.Namespace = cluster.GetNamespace()
+		svc.Spec = corev1.ServiceSpec{
+			Selector:  labels,
+			ClusterIP: "None",
+			Ports: []corev1.ServicePort{
+				{Name: "stateboard", Port: stateboardPort, Protocol: corev1.ProtocolTCP},
+			},
+		}
+		if err := controllerutil.SetControllerReference(cluster, svc, r.Scheme); err != nil {
+			return "", err
+		}
+		if err := r.Create(ctx, svc); err != nil {
+			return "", err
+		}
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: name}, sts); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+
+		replicas := int32(1)
+		sts.Name = name
+		sts.Namespace = cluster.GetNamespace()
+		sts.Spec = appsv1.StatefulSetSpec{
+			ServiceName: name,
+			Replicas:    &replicas,
+			Selector:    &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "stateboard",
+							Image: params.Image,
+							Env: []corev1.EnvVar{
+								{Name: "TARANTOOL_LISTEN", Value: fmt.Sprintf("0.0.0.0:%d", stateboardPort)},
+								{Name: "TARANTOOL_PASSWORD", Value: password},
+							},
+							Ports: []corev1.ContainerPort{{Name: "stateboard", ContainerPort: stateboardPort}},
+						},
+					},
+				},
+			},
+		}
+		if err := controllerutil.SetControllerReference(cluster, sts, r.Scheme); err != nil {
+			return "", err
+		}
+		if err := r.Create(ctx, sts); err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("%s-0.%s.%s:%d", name, name, cluster.GetNamespace(), stateboardPort), nil
+}
+
+// buildFailoverConfig resolves cluster.Spec.Failover into a topology.FailoverConfig,
+// fetching the etcd2/stateboard password Secret it references, if any. A nil
+// Spec.Failover resolves to FailoverModeEventual, preserving the operator's original
+// behavior of enabling eventual failover once vshard is bootstrapped.
+func (r *ClusterReconciler) buildFailoverConfig(ctx context.Context, cluster *tarantooliov1alpha1.Cluster) (topology.FailoverConfig, error) {
+	failover := cluster.Spec.Failover
+	if failover == nil {
+		return topology.FailoverConfig{Mode: topology.FailoverModeEventual}, nil
+	}
+
+	cfg := topology.FailoverConfig{Mode: topology.FailoverMode(failover.Mode)}
+	if cfg.Mode == "" {
+		cfg.Mode = topology.FailoverModeEventual
+	}
+
+	if cfg.Mode != topology.FailoverModeStateful {
+		return cfg, nil
+	}
+
+	switch failover.StateProvider {
+	case tarantooliov1alpha1.FailoverStateProviderETCD2:
+		cfg.StateProvider = topology.FailoverStateProviderETCD2
+
+		params := failover.ETCD2
+		etcd2 := &topology.ETCD2FailoverParams{
+			Endpoints: params.Endpoints,
+			Prefix:    params.Prefix,
+			Username:  params.Username,
+		}
+		if params.LockDelay != nil {
+			etcd2.LockDelay = params.LockDelay.Seconds()
+		}
+		if params.PasswordSecretRef != nil {
+			secret := &corev1.Secret{}
+			name := types.NamespacedName{Namespace: cluster.GetNamespace(), Name: params.PasswordSecretRef.Name}
+			if err := r.Get(ctx, name, secret); err != nil {
+				return topology.FailoverConfig{}, fmt.Errorf("getting etcd2 password secret %s: %w", params.PasswordSecretRef.Name, err)
+			}
+			etcd2.Password = string(secret.Data["password"])
+		}
+		cfg.ETCD2 = etcd2
+	case tarantooliov1alpha1.FailoverStateProviderStateboard:
+		cfg.StateProvider = topology.FailoverStateProviderStateboard
+
+		params := failover.Stateboard
+		stateboard := &topology.StateboardFailoverParams{URI: params.URI}
+		if params.PasswordSecretRef != nil {
+			secret := &corev1.Secret{}
+			name := types.NamespacedName{Namespace: cluster.GetNamespace(), Name: params.PasswordSecretRef.Name}
+			if err := r.Get(ctx, name, secret); err != nil {
+				return topology.FailoverConfig{}, fmt.Errorf("getting stateboard password secret %s: %w", params.PasswordSecretRef.Name, err)
+			}
+			stateboard.Password = string(secret.Data["password"])
+		}
+
+		if params.Managed {
+			uri, err := r.reconcileStateboard(ctx, cluster, params, stateboard.Password)
+			if err != nil {
+				return topology.FailoverConfig{}, fmt.Errorf("reconciling managed stateboard: %w", err)
+			}
+			stateboard.URI = uri
+		}
+
+		cfg.Stateboard = stateboard
+	}
+
+	return cfg, nil
+}
+
+// failoverStatusFromConfig converts a topology.FailoverConfig applied by SetFailover
+// into the tarantooliov1alpha1.FailoverStatus recorded on the Cluster, translating
+// topology's wire-level "tarantool" state provider back to the CRD's "stateboard" name.
+func failoverStatusFromConfig(cfg topology.FailoverConfig) *tarantooliov1alpha1.FailoverStatus {
+	status := &tarantooliov1alpha1.FailoverStatus{Mode: tarantooliov1alpha1.FailoverMode(cfg.Mode)}
+
+	switch cfg.StateProvider {
+	case topology.FailoverStateProviderETCD2:
+		status.StateProvider = tarantooliov1alpha1.FailoverStateProviderETCD2
+	case topology.FailoverStateProviderStateboard:
+		status.StateProvider = tarantooliov1alpha1.FailoverStateProviderStateboard
+	}
+
+	return status
+}
+
+// editReplicasetWeightOptions builds the topology.EditReplicasetOptions a weight-change
+// call site passes to EditReplicaset: the parsed weight, plus sts's vshard_group when
+// tarantool.io/useVshardGroups is set, so a replicaset's group assignment is
+// re-asserted every time its weight is touched instead of only at Join time.
+func editReplicasetWeightOptions(sts *appsv1.StatefulSet, weight string) (topology.EditReplicasetOptions, error) {
+	w, err := strconv.ParseFloat(weight, 64)
+	if err != nil {
+		return topology.EditReplicasetOptions{}, fmt.Errorf("parsing weight %q: %w", weight, err)
+	}
+
+	opts := topology.EditReplicasetOptions{Weight: &w}
+
+	labels := sts.GetLabels()
+	if labels["tarantool.io/useVshardGroups"] == "1" {
+		opts.VshardGroup = labels["tarantool.io/vshardGroupName"]
+	}
+
+	return opts, nil
+}
+
+// clusterReferencesSecret reports whether cluster's Auth config references secretName,
+// so a Secret watch can map rotations back to just the Clusters that depend on them.
+func clusterReferencesSecret(cluster *tarantooliov1alpha1.Cluster, secretName string) bool {
+	if auth := cluster.Spec.Auth; auth != nil {
+		if auth.SecretRef != nil && auth.SecretRef.Name == secretName {
+			return true
+		}
+		if auth.TLS != nil {
+			if auth.TLS.CASecretRef != nil && auth.TLS.CASecretRef.Name == secretName {
+				return true
+			}
+			if auth.TLS.ClientCertSecretRef != nil && auth.TLS.ClientCertSecretRef.Name == secretName {
+				return true
+			}
+		}
+	}
+
+	if failover := cluster.Spec.Failover; failover != nil {
+		if failover.ETCD2 != nil && failover.ETCD2.PasswordSecretRef != nil && failover.ETCD2.PasswordSecretRef.Name == secretName {
+			return true
+		}
+		if failover.Stateboard != nil && failover.Stateboard.PasswordSecretRef != nil && failover.Stateboard.PasswordSecretRef.Name == secretName {
+			return true
+		}
+	}
+
+	return false
+}
+
 //+kubebuilder:rbac:groups=tarantool.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=tarantool.io,resources=clusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=tarantool.io,resources=clusters/finalizers,verbs=update
@@ -108,6 +591,9 @@ func SetInstanceUUID(o *corev1.Pod) *corev1.Pod {
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;create;update;watch;list;patch;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;create;update;watch;list;patch;delete
 //+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;create;update;watch;list;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update;watch;list;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -124,26 +610,26 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	// do nothing if no Cluster
 	cluster := &tarantooliov1alpha1.Cluster{}
-	if err := r.Get(context.TODO(), req.NamespacedName, cluster); err != nil {
+	if err := r.Get(ctx, req.NamespacedName, cluster); err != nil {
 		if errors.IsNotFound(err) {
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+			return ctrl.Result{}, nil
 		}
 
-		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+		return ctrl.Result{}, err
 	}
 
 	clusterSelector, err := metav1.LabelSelectorAsSelector(cluster.Spec.Selector)
 	if err != nil {
-		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+		return ctrl.Result{}, err
 	}
 
 	roleList := &tarantooliov1alpha1.RoleList{}
-	if err := r.List(context.TODO(), roleList, &client.ListOptions{LabelSelector: clusterSelector, Namespace: req.NamespacedName.Namespace}); err != nil {
+	if err := r.List(ctx, roleList, &client.ListOptions{LabelSelector: clusterSelector, Namespace: req.NamespacedName.Namespace}); err != nil {
 		if errors.IsNotFound(err) {
 			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 		}
 
-		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+		return ctrl.Result{}, err
 	}
 
 	for _, role := range roleList.Items {
@@ -158,20 +644,23 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		annotations["tarantool.io/cluster-id"] = cluster.GetName()
 		role.SetAnnotations(annotations)
 		if err := controllerutil.SetControllerReference(cluster, &role, r.Scheme); err != nil {
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+			return ctrl.Result{}, err
 		}
-		if err := r.Update(context.TODO(), &role); err != nil {
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+		if err := r.Update(ctx, &role); err != nil {
+			return ctrl.Result{}, err
 		}
 
 		reqLogger.Info("Set role ownership", "Role.Name", role.GetName(), "Cluster.Name", cluster.GetName())
 	}
 
 	reqLogger.Info("Roles reconciled, moving to pod reconcile")
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.RolesReconciled, tarantooliov1alpha1.ConditionTrue, "Reconciled", "every Role is owned by this Cluster"); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	// ensure cluster wide Service exists
 	svc := &corev1.Service{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}, svc); err != nil {
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}, svc); err != nil {
 		if errors.IsNotFound(err) {
 			svc.Name = cluster.GetName()
 			svc.Namespace = cluster.GetNamespace()
@@ -188,51 +677,113 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			}
 
 			if err := controllerutil.SetControllerReference(cluster, svc, r.Scheme); err != nil {
-				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+				return ctrl.Result{}, err
 			}
 
-			if err := r.Create(context.TODO(), svc); err != nil {
-				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+			if err := r.Create(ctx, svc); err != nil {
+				return ctrl.Result{}, err
 			}
 		}
 	}
 
 	// ensure Cluster leader elected
 	ep := &corev1.Endpoints{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}, ep); err != nil {
-		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}, ep); err != nil {
+		return ctrl.Result{}, err
 	}
 	if len(ep.Subsets) == 0 || len(ep.Subsets[0].Addresses) == 0 {
 		reqLogger.Info("No available Endpoint resource configured for Cluster, waiting")
 		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 	}
 
-	if !IsLeaderExists(ep) {
-		leader := fmt.Sprintf("%s:%s", ep.Subsets[0].Addresses[0].IP, "8081")
+	leaseTracker := &leaderlease.Tracker{Client: r.Client, Scheme: r.Scheme}
+	lease, err := leaseTracker.Get(ctx, cluster.GetNamespace(), cluster.GetName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	leaderAddress := ""
+	if IsLeaderExists(lease) {
+		leaderAddress = *lease.Spec.HolderIdentity
+
+		// Refresh status.leader from the Lease on every reconcile, not just when this
+		// reconcile elects a new holder, so a stale Since/ExpiresAt (or a nil Leader,
+		// e.g. after a Status subresource reset) doesn't linger for the rest of the
+		// current holder's lease.
+		since := metav1.NewTime(lease.Spec.AcquireTime.Time)
+		expiresAt := metav1.NewTime(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+		cluster.Status.Leader = &tarantooliov1alpha1.ClusterLeader{Pod: leaderAddress, Since: &since, ExpiresAt: &expiresAt}
+	} else {
+		candidates := r.buildLeaderCandidates(ctx, cluster, ep)
+
+		elected, err := leaderelection.ForStrategy(cluster.Spec.LeaderElection).Elect(ctx, candidates)
+		if err != nil {
+			reqLogger.Info("no leader candidate available yet, waiting", "error", err.Error())
+			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+		}
 
-		if ep.Annotations == nil {
-			ep.Annotations = make(map[string]string)
+		leaseDuration := defaultLeaderLeaseDuration
+		if cluster.Spec.LeaderLeaseDuration != nil {
+			leaseDuration = cluster.Spec.LeaderLeaseDuration.Duration
 		}
 
-		ep.Annotations["tarantool.io/leader"] = leader
-		if err := r.Update(context.TODO(), ep); err != nil {
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+		now := time.Now()
+		lease, err = leaseTracker.Renew(ctx, cluster, cluster.GetNamespace(), cluster.GetName(), elected.Address, leaseDuration, now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		leaderAddress = elected.Address
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "LeaderElected", "elected %s as cluster leader", leaderAddress)
+
+		// Mirror the holder into the Endpoints annotation for one release, so anything
+		// still reading tarantool.io/leader directly doesn't break during the rollout.
+		if err := kubeutil.UpdateWithRetry(ctx, r.Client, ep, func(o client.Object) error {
+			endpoints := o.(*corev1.Endpoints)
+			if endpoints.Annotations == nil {
+				endpoints.Annotations = make(map[string]string)
+			}
+			endpoints.Annotations["tarantool.io/leader"] = leaderAddress
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, err
 		}
+
+		since := metav1.NewTime(now)
+		expiresAt := metav1.NewTime(now.Add(leaseDuration))
+		cluster.Status.Leader = &tarantooliov1alpha1.ClusterLeader{Pod: leaderAddress, Since: &since, ExpiresAt: &expiresAt}
+	}
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.LeaderElected, tarantooliov1alpha1.ConditionTrue, "Elected", fmt.Sprintf("leader is %s", leaderAddress)); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	stsList := &appsv1.StatefulSetList{}
-	if err := r.List(context.TODO(), stsList, &client.ListOptions{LabelSelector: clusterSelector, Namespace: req.NamespacedName.Namespace}); err != nil {
+	if err := r.List(ctx, stsList, &client.ListOptions{LabelSelector: clusterSelector, Namespace: req.NamespacedName.Namespace}); err != nil {
 		if errors.IsNotFound(err) {
 			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 		}
 
-		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+		return ctrl.Result{}, err
+	}
+
+	authOpts, err := r.buildTopologyOptions(ctx, cluster)
+	if err != nil {
+		reqLogger.Error(err, "failed to build topology auth options")
+		return ctrl.Result{}, err
+	}
+
+	scheme := "http"
+	if cluster.Spec.Auth != nil && cluster.Spec.Auth.TLS != nil {
+		scheme = "https"
 	}
 
-	topologyClient := topology.NewBuiltInTopologyService(
-		topology.WithTopologyEndpoint(fmt.Sprintf("http://%s/admin/api", ep.Annotations["tarantool.io/leader"])),
+	// topologyClient is pinned to leaderAddress for the rest of this reconcile. The
+	// Join loop below releases the leader Lease and requeues on topology.IsConnectionError
+	// so a dead leader gets re-elected on the next reconcile instead of waiting out its
+	// lease; the maintenance/draining/upgrade call sites further down don't do this yet.
+	topologyClient := topology.NewBuiltInTopologyService(append([]topology.Option{
+		topology.WithTopologyEndpoint(fmt.Sprintf("%s://%s/admin/api", scheme, leaderAddress)),
 		topology.WithClusterID(cluster.GetName()),
-	)
+	}, authOpts...)...)
 
 	for _, sts := range stsList.Items {
 		for i := 0; i < int(*sts.Spec.Replicas); i++ {
@@ -241,12 +792,12 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				Namespace: req.Namespace,
 				Name:      fmt.Sprintf("%s-%d", sts.GetName(), i),
 			}
-			if err := r.Get(context.TODO(), name, pod); err != nil {
+			if err := r.Get(ctx, name, pod); err != nil {
 				if errors.IsNotFound(err) {
 					return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 				}
 
-				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+				return ctrl.Result{}, err
 			}
 
 			podLogger := reqLogger.WithValues("Pod.Name", pod.GetName())
@@ -256,8 +807,8 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			podLogger.Info("starting: set instance uuid")
 			pod = SetInstanceUUID(pod)
 
-			if err := r.Update(context.TODO(), pod); err != nil {
-				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+			if err := r.Update(ctx, pod); err != nil {
+				return ctrl.Result{}, err
 			}
 
 			podLogger.Info("success: set instance uuid", "UUID", pod.GetLabels()["tarantool.io/instance-uuid"])
@@ -270,23 +821,35 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				Namespace: req.Namespace,
 				Name:      fmt.Sprintf("%s-%d", sts.GetName(), i),
 			}
-			if err := r.Get(context.TODO(), name, pod); err != nil {
+			if err := r.Get(ctx, name, pod); err != nil {
 				if errors.IsNotFound(err) {
 					return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 				}
 
-				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+				return ctrl.Result{}, err
 			}
 
 			if tarantool.IsJoined(pod) {
 				continue
 			}
 
+			joinTTL := defaultJoinTTL
+			if cluster.Spec.JoinTTL != nil {
+				joinTTL = cluster.Spec.JoinTTL.Duration
+			}
+
+			if tarantool.FailedToJoin(pod, joinTTL) {
+				if err := r.remediateFailedJoin(ctx, cluster, pod); err != nil {
+					return ctrl.Result{}, err
+				}
+				continue
+			}
+
 			if err := topologyClient.Join(pod); err != nil {
 				if topology.IsAlreadyJoined(err) {
 					tarantool.MarkJoined(pod)
-					if err := r.Update(context.TODO(), pod); err != nil {
-						return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+					if err := r.Update(ctx, pod); err != nil {
+						return ctrl.Result{}, err
 					}
 					reqLogger.Info("Already joined", "Pod.Name", pod.Name)
 					continue
@@ -297,47 +860,65 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 					continue
 				}
 
+				if topology.IsConnectionError(err) {
+					reqLogger.Info("leader unreachable, releasing lease for re-election", "leader", leaderAddress, "error", err.Error())
+					if rerr := leaseTracker.Release(ctx, cluster.GetNamespace(), cluster.GetName()); rerr != nil {
+						reqLogger.Error(rerr, "failed to release leader lease")
+					}
+					r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "LeaderUnreachable", "leader %s is unreachable, re-electing: %s", leaderAddress, err.Error())
+					if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.LeaderElected, tarantooliov1alpha1.ConditionFalse, "Unreachable", err.Error()); cerr != nil {
+						reqLogger.Error(cerr, "failed to record LeaderElected condition")
+					}
+					return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+				}
+
 				reqLogger.Error(err, "Join error")
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "JoinFailed", "failed to join %s to the cluster: %s", pod.GetName(), err.Error())
+				if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.PodsJoined, tarantooliov1alpha1.ConditionFalse, "JoinFailed", err.Error()); cerr != nil {
+					reqLogger.Error(cerr, "failed to record PodsJoined condition")
+				}
 				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 			} else {
 				tarantool.MarkJoined(pod)
-				if err := r.Update(context.TODO(), pod); err != nil {
-					return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+				if err := r.Update(ctx, pod); err != nil {
+					return ctrl.Result{}, err
 				}
 			}
 
 			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 		}
 	}
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.PodsJoined, tarantooliov1alpha1.ConditionTrue, "Joined", "every Pod has an instance-uuid and has joined the cluster"); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileConfig(ctx, cluster, topologyClient); err != nil {
+		reqLogger.Error(err, "config reconcile error")
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "ConfigApplyFailed", "failed to apply cluster config: %s", err.Error())
+		if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.ConfigApplied, tarantooliov1alpha1.ConditionFalse, "ApplyFailed", err.Error()); cerr != nil {
+			reqLogger.Error(cerr, "failed to record ConfigApplied condition")
+		}
+		return ctrl.Result{}, err
+	}
+
+	rebalanceDue, maintenanceRequeueAfter, err := r.reconcileMaintenance(ctx, cluster, stsList, topologyClient, time.Now())
+	if err != nil {
+		reqLogger.Error(err, "maintenance window reconcile error")
+		return ctrl.Result{}, err
+	}
 
 	for _, sts := range stsList.Items {
 		stsAnnotations := sts.GetAnnotations()
 		weight := stsAnnotations["tarantool.io/replicaset-weight"]
 
-		if weight == "0" {
-			reqLogger.Info("weight is set to 0, checking replicaset buckets for scheduled deletion")
-			data, err := topologyClient.GetServerStat()
+		if weight == "0" && stsAnnotations[annotationScheduledDelete] != "1" {
+			result, err := r.reconcileDraining(ctx, cluster, &sts, topologyClient)
 			if err != nil {
-				reqLogger.Error(err, "failed to get server stats")
-			} else {
-				for i := 0; i < len(data.Stats); i++ {
-					if strings.HasPrefix(data.Stats[i].URI, sts.GetName()) {
-						reqLogger.Info("Found statefulset to check for buckets count", "sts.Name", sts.GetName())
-
-						bucketsCount := data.Stats[i].Statistics.BucketsCount
-						if bucketsCount == 0 {
-							reqLogger.Info("replicaset has migrated all of its buckets away, schedule to remove", "sts.Name", sts.GetName())
-
-							stsAnnotations["tarantool.io/scheduledDelete"] = "1"
-							sts.SetAnnotations(stsAnnotations)
-							if err := r.Update(context.TODO(), &sts); err != nil {
-								reqLogger.Error(err, "failed to set scheduled deletion annotation")
-							}
-						} else {
-							reqLogger.Info("replicaset still has buckets, retry checking on next run", "sts.Name", sts.GetName(), "buckets", bucketsCount)
-						}
-					}
-				}
+				reqLogger.Error(err, "draining phase error", "StatefulSet.Name", sts.GetName())
+				return ctrl.Result{}, err
+			}
+			if result.Requeue || result.RequeueAfter > 0 {
+				return result, nil
 			}
 		}
 
@@ -348,12 +929,12 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				Name:      fmt.Sprintf("%s-%d", sts.GetName(), i),
 			}
 
-			if err := r.Get(context.TODO(), name, pod); err != nil {
+			if err := r.Get(ctx, name, pod); err != nil {
 				if errors.IsNotFound(err) {
 					return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 				}
 
-				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+				return ctrl.Result{}, err
 			}
 
 			if !tarantool.IsJoined(pod) {
@@ -362,10 +943,22 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			}
 		}
 
-		if err := topologyClient.SetWeight(sts.GetLabels()["tarantool.io/replicaset-uuid"], weight); err != nil {
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+		if !rebalanceDue {
+			reqLogger.Info("rebalance/weightApply maintenance window hasn't fired yet, deferring weight change", "StatefulSet.Name", sts.GetName())
+			continue
+		}
+
+		opts, err := editReplicasetWeightOptions(&sts, weight)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := topologyClient.EditReplicaset(sts.GetLabels()["tarantool.io/replicaset-uuid"], opts); err != nil {
+			return ctrl.Result{}, err
 		}
 	}
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.WeightsApplied, tarantooliov1alpha1.ConditionTrue, "Applied", "every replicaset's weight matches its StatefulSet annotation"); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	for _, sts := range stsList.Items {
 		replicasetUUID := sts.GetLabels()["tarantool.io/replicaset-uuid"]
@@ -373,13 +966,13 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		actualRoles, err := topologyClient.GetReplicasetRolesFromService(replicasetUUID)
 		if err != nil {
 			reqLogger.Error(err, "Getting roles from server")
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+			return ctrl.Result{}, err
 		}
 
 		desireRoles, err := topology.GetRoles(&sts.ObjectMeta)
 		if err != nil {
 			reqLogger.Error(err, "Getting roles from statefulset")
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+			return ctrl.Result{}, err
 		}
 
 		if utils.IsRolesEquals(actualRoles, desireRoles) {
@@ -390,75 +983,933 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		err = topologyClient.SetReplicasetRoles(replicasetUUID, desireRoles)
 		if err != nil {
 			reqLogger.Error(err, "Setting new replicaset roles")
-			return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+			return ctrl.Result{}, err
 		}
 	}
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.RolesApplied, tarantooliov1alpha1.ConditionTrue, "Applied", "every replicaset's roles match its ReplicasetTemplate"); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	for _, sts := range stsList.Items {
 		stsAnnotations := sts.GetAnnotations()
 		if stsAnnotations["tarantool.io/isBootstrapped"] != "1" {
 			reqLogger.Info("cluster is not bootstrapped, bootstrapping", "Statefulset.Name", sts.GetName())
-			if err := topologyClient.BootstrapVshard(); err != nil {
+
+			stsLabels := sts.GetLabels()
+			bootstrap := topologyClient.BootstrapVshard
+			if stsLabels["tarantool.io/useVshardGroups"] == "1" {
+				group := stsLabels["tarantool.io/vshardGroupName"]
+				bootstrap = func() error { return topologyClient.BootstrapVshardGroup(group) }
+			}
+
+			if err := bootstrap(); err != nil {
 				if topology.IsAlreadyBootstrapped(err) {
 					stsAnnotations["tarantool.io/isBootstrapped"] = "1"
 					sts.SetAnnotations(stsAnnotations)
 
-					if err := r.Update(context.TODO(), &sts); err != nil {
+					if err := r.Update(ctx, &sts); err != nil {
 						reqLogger.Error(err, "failed to set bootstrapped annotation")
 					}
 
 					reqLogger.Info("Added bootstrapped annotation", "StatefulSet.Name", sts.GetName())
+					r.Recorder.Event(cluster, corev1.EventTypeNormal, "VshardAlreadyBootstrapped", "vshard was already bootstrapped, recording it on the StatefulSet")
 
 					cluster.Status.State = "Ready"
-					err = r.Status().Update(context.TODO(), cluster)
-					if err != nil {
-						return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+					if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.VshardBootstrapped, tarantooliov1alpha1.ConditionTrue, "Bootstrapped", "vshard was already bootstrapped"); err != nil {
+						return ctrl.Result{}, err
 					}
 					return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
 				}
 
 				reqLogger.Error(err, "Bootstrap vshard error")
-				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, err
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "BootstrapFailed", "failed to bootstrap vshard: %s", err.Error())
+				if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.VshardBootstrapped, tarantooliov1alpha1.ConditionFalse, "BootstrapFailed", err.Error()); cerr != nil {
+					reqLogger.Error(cerr, "failed to record VshardBootstrapped condition")
+				}
+				return ctrl.Result{}, err
 			}
 		} else {
 			reqLogger.Info("cluster is already bootstrapped, not retrying", "Statefulset.Name", sts.GetName())
 		}
 
+		cluster.Status.State = "Ready"
+		if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.VshardBootstrapped, tarantooliov1alpha1.ConditionTrue, "Bootstrapped", "vshard has been bootstrapped"); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		if stsAnnotations["tarantool.io/failoverEnabled"] == "1" {
 			reqLogger.Info("failover is enabled, not retrying")
+		} else if failoverCfg, err := r.buildFailoverConfig(ctx, cluster); err != nil {
+			reqLogger.Error(err, "failed to resolve failover configuration")
+			if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.FailoverEnabled, tarantooliov1alpha1.ConditionFalse, "EnableFailed", err.Error()); cerr != nil {
+				reqLogger.Error(cerr, "failed to record FailoverEnabled condition")
+			}
+		} else if err := topologyClient.SetFailover(failoverCfg); err != nil {
+			reqLogger.Error(err, "failed to enable cluster failover")
+			if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.FailoverEnabled, tarantooliov1alpha1.ConditionFalse, "EnableFailed", err.Error()); cerr != nil {
+				reqLogger.Error(cerr, "failed to record FailoverEnabled condition")
+			}
 		} else {
-			if err := topologyClient.SetFailover(true); err != nil {
-				reqLogger.Error(err, "failed to enable cluster failover")
-			} else {
-				reqLogger.Info("enabled failover")
+			reqLogger.Info("enabled failover")
 
-				stsAnnotations["tarantool.io/failoverEnabled"] = "1"
-				sts.SetAnnotations(stsAnnotations)
-				if err := r.Update(context.TODO(), &sts); err != nil {
-					reqLogger.Error(err, "failed to set failover enabled annotation")
-				}
+			stsAnnotations["tarantool.io/failoverEnabled"] = "1"
+			sts.SetAnnotations(stsAnnotations)
+			if err := r.Update(ctx, &sts); err != nil {
+				reqLogger.Error(err, "failed to set failover enabled annotation")
+			}
+
+			cluster.Status.Failover = failoverStatusFromConfig(failoverCfg)
+		}
+
+		if stsAnnotations["tarantool.io/failoverEnabled"] == "1" {
+			if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.FailoverEnabled, tarantooliov1alpha1.ConditionTrue, "Enabled", "cluster-wide failover is enabled"); err != nil {
+				return ctrl.Result{}, err
 			}
 		}
 	}
 
-	return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+	if result, err := r.reconcileManualExpel(ctx, cluster, stsList, topologyClient); err != nil {
+		reqLogger.Error(err, "manual expel phase error")
+		return ctrl.Result{}, err
+	} else if result.Requeue || result.RequeueAfter > 0 {
+		return result, nil
+	}
+
+	if result, err := r.reconcileUpgrades(ctx, cluster, stsList, topologyClient); err != nil {
+		reqLogger.Error(err, "upgrade phase error")
+		return ctrl.Result{}, err
+	} else if result.Requeue || result.RequeueAfter > 0 {
+		return result, nil
+	}
+
+	requeueAfter := time.Duration(5 * time.Second)
+	if maintenanceRequeueAfter != nil && *maintenanceRequeueAfter < requeueAfter {
+		requeueAfter = *maintenanceRequeueAfter
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&tarantooliov1alpha1.Cluster{}).
-		Watches(&source.Kind{Type: &tarantooliov1alpha1.Cluster{}}, &handler.EnqueueRequestForObject{}).
-		Watches(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
-			if a.GetLabels() == nil {
-				return []ctrl.Request{}
-			}
-			return []ctrl.Request{
-				{NamespacedName: types.NamespacedName{
-					Namespace: a.GetNamespace(),
-					Name:      a.GetLabels()["tarantool.io/cluster-id"],
-				}},
+// reconcileConfig diffs cluster.Spec.Config against what Cartridge currently reports
+// for those same sections and, if any differ, pushes only the differing sections
+// through topologyClient.ApplyConfig -- Cartridge applies edit_config with its own
+// two-phase commit across every instance, so reconcileConfig doesn't need a lock of its
+// own beyond the leader Lease that already ensures only one reconcile is talking to
+// this Cluster's admin API at a time. It's a no-op when Spec.Config is empty, so
+// Clusters that don't use it see no behavior change.
+func (r *ClusterReconciler) reconcileConfig(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, topologyClient *topology.BuiltInTopologyService) error {
+	if len(cluster.Spec.Config) == 0 {
+		if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.ConfigApplied, tarantooliov1alpha1.ConditionTrue, "NotConfigured", "Spec.Config is empty"); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	reqLogger := log.FromContext(ctx)
+
+	sections := make([]string, 0, len(cluster.Spec.Config))
+	for filename := range cluster.Spec.Config {
+		sections = append(sections, filename)
+	}
+
+	actual, err := topologyClient.GetConfig(sections)
+	if err != nil {
+		return fmt.Errorf("reading clusterwide config: %w", err)
+	}
+
+	diff := make(map[string][]byte)
+	for filename, desired := range cluster.Spec.Config {
+		if string(actual[filename]) != desired {
+			diff[filename] = []byte(desired)
+		}
+	}
+
+	if len(diff) == 0 {
+		reqLogger.Info("cluster config already matches Spec.Config")
+		return r.setCondition(ctx, cluster, tarantooliov1alpha1.ConfigApplied, tarantooliov1alpha1.ConditionTrue, "Applied", "cluster config matches Spec.Config")
+	}
+
+	changed := make([]string, 0, len(diff))
+	for filename := range diff {
+		changed = append(changed, filename)
+	}
+	reqLogger.Info("applying cluster config", "sections", changed)
+
+	if err := topologyClient.ApplyConfig(diff); err != nil {
+		return fmt.Errorf("applying clusterwide config: %w", err)
+	}
+
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ConfigApplied", "applied config sections: %v", changed)
+
+	return r.setCondition(ctx, cluster, tarantooliov1alpha1.ConfigApplied, tarantooliov1alpha1.ConditionTrue, "Applied", "cluster config matches Spec.Config")
+}
+
+// reconcileMaintenance evaluates cluster.Spec.Maintenance against now. For a window whose
+// scheduled instant hasn't been recorded yet, it seeds cluster.Status.NextScheduledRuns from
+// MaintenanceWindow.Next. For a window that has come due, it either reports that the
+// weight-apply loop above may run (MaintenanceOperationRebalance / MaintenanceOperationWeightApply)
+// or performs the action directly (MaintenanceOperationSnapshot / MaintenanceOperationFailoverProbe /
+// MaintenanceOperationRolesDrift), then reschedules from now. It returns whether a rebalance/weightApply window is due this
+// reconcile, and the time until the soonest upcoming window so Reconcile can requeue for it
+// instead of the default poll interval. When no Maintenance windows are configured it
+// returns true and a nil requeueAfter, so weights are applied on every reconcile.
+func (r *ClusterReconciler) reconcileMaintenance(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, stsList *appsv1.StatefulSetList, topologyClient *topology.BuiltInTopologyService, now time.Time) (rebalanceDue bool, requeueAfter *time.Duration, err error) {
+	if len(cluster.Spec.Maintenance) == 0 {
+		return true, nil, nil
+	}
+
+	reqLogger := log.FromContext(ctx)
+
+	if cluster.Status.NextScheduledRuns == nil {
+		cluster.Status.NextScheduledRuns = map[tarantooliov1alpha1.MaintenanceOperation]metav1.Time{}
+	}
+
+	statusChanged := false
+	for _, window := range cluster.Spec.Maintenance {
+		next, scheduled := cluster.Status.NextScheduledRuns[window.Operation]
+
+		if !scheduled || !now.Before(next.Time) {
+			if scheduled {
+				switch window.Operation {
+				case tarantooliov1alpha1.MaintenanceOperationRebalance, tarantooliov1alpha1.MaintenanceOperationWeightApply:
+					rebalanceDue = true
+				case tarantooliov1alpha1.MaintenanceOperationSnapshot:
+					r.runMaintenanceSnapshot(ctx, cluster, topologyClient)
+				case tarantooliov1alpha1.MaintenanceOperationFailoverProbe:
+					r.runMaintenanceFailoverProbe(ctx, cluster, topologyClient)
+				case tarantooliov1alpha1.MaintenanceOperationRolesDrift:
+					r.runMaintenanceRolesDrift(ctx, cluster, stsList, topologyClient)
+				}
+			}
+
+			fireAt, nextErr := window.Next(now)
+			if nextErr != nil {
+				return false, nil, nextErr
+			}
+			cluster.Status.NextScheduledRuns[window.Operation] = metav1.NewTime(fireAt)
+			statusChanged = true
+			next = cluster.Status.NextScheduledRuns[window.Operation]
+		}
+
+		untilNext := next.Time.Sub(now)
+		if requeueAfter == nil || untilNext < *requeueAfter {
+			requeueAfter = &untilNext
+		}
+	}
+
+	if statusChanged {
+		if err := r.Status().Update(ctx, cluster); err != nil {
+			reqLogger.Error(err, "failed to persist maintenance schedule")
+			return false, nil, err
+		}
+	}
+
+	return rebalanceDue, requeueAfter, nil
+}
+
+// runMaintenanceSnapshot triggers an on-demand snapshot for a due MaintenanceOperationSnapshot
+// window and records the outcome through the condition+event system, mirroring the other
+// topologyClient call sites above.
+func (r *ClusterReconciler) runMaintenanceSnapshot(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, topologyClient *topology.BuiltInTopologyService) {
+	reqLogger := log.FromContext(ctx)
+
+	if err := topologyClient.Snapshot(); err != nil {
+		reqLogger.Error(err, "scheduled snapshot failed")
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "MaintenanceSnapshotFailed", "scheduled snapshot failed: %s", err.Error())
+		if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.MaintenanceRun, tarantooliov1alpha1.ConditionFalse, "SnapshotFailed", err.Error()); cerr != nil {
+			reqLogger.Error(cerr, "failed to record MaintenanceRun condition")
+		}
+		return
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "MaintenanceSnapshotSucceeded", "scheduled snapshot completed")
+	if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.MaintenanceRun, tarantooliov1alpha1.ConditionTrue, "SnapshotSucceeded", "scheduled snapshot completed"); cerr != nil {
+		reqLogger.Error(cerr, "failed to record MaintenanceRun condition")
+	}
+}
+
+// runMaintenanceFailoverProbe re-asserts cluster-wide failover for a due
+// MaintenanceOperationFailoverProbe window, in case it drifted off since it was last
+// configured, or drifted to a different mode/state provider than Spec.Failover requests.
+func (r *ClusterReconciler) runMaintenanceFailoverProbe(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, topologyClient *topology.BuiltInTopologyService) {
+	reqLogger := log.FromContext(ctx)
+
+	failoverCfg, err := r.buildFailoverConfig(ctx, cluster)
+	if err != nil {
+		reqLogger.Error(err, "failed to resolve failover configuration")
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "MaintenanceFailoverProbeFailed", "failed to resolve failover configuration: %s", err.Error())
+		if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.MaintenanceRun, tarantooliov1alpha1.ConditionFalse, "FailoverProbeFailed", err.Error()); cerr != nil {
+			reqLogger.Error(cerr, "failed to record MaintenanceRun condition")
+		}
+		return
+	}
+
+	status, err := topologyClient.GetFailoverStatus()
+	if err == nil && status.Mode == failoverCfg.Mode && (failoverCfg.Mode != topology.FailoverModeStateful || status.StateProvider == failoverCfg.StateProvider) {
+		return
+	}
+
+	if err := topologyClient.SetFailover(failoverCfg); err != nil {
+		reqLogger.Error(err, "scheduled failover probe failed")
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "MaintenanceFailoverProbeFailed", "scheduled failover probe failed: %s", err.Error())
+		if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.MaintenanceRun, tarantooliov1alpha1.ConditionFalse, "FailoverProbeFailed", err.Error()); cerr != nil {
+			reqLogger.Error(cerr, "failed to record MaintenanceRun condition")
+		}
+		return
+	}
+
+	cluster.Status.Failover = failoverStatusFromConfig(failoverCfg)
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "MaintenanceFailoverProbeSucceeded", "scheduled failover probe re-asserted the configured failover mode")
+	if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.MaintenanceRun, tarantooliov1alpha1.ConditionTrue, "FailoverProbeSucceeded", "failover is configured as expected"); cerr != nil {
+		reqLogger.Error(cerr, "failed to record MaintenanceRun condition")
+	}
+}
+
+// runMaintenanceRolesDrift re-asserts every StatefulSet in stsList's tarantool.io/rolesToAssign
+// roles against what Cartridge currently has assigned to its replicaset, in case a
+// cartridge-side change (an admin editing roles out-of-band, or a node rejoining with
+// stale roles) drifted it away from the desired state RoleReconciler maintains on the
+// StatefulSet itself. Skips a StatefulSet with no replicaset-uuid label or no
+// rolesToAssign annotation/label, since neither is something this controller manages.
+func (r *ClusterReconciler) runMaintenanceRolesDrift(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, stsList *appsv1.StatefulSetList, topologyClient *topology.BuiltInTopologyService) {
+	reqLogger := log.FromContext(ctx)
+
+	var corrected []string
+	for i := range stsList.Items {
+		sts := &stsList.Items[i]
+
+		replicasetUUID := sts.GetLabels()["tarantool.io/replicaset-uuid"]
+		if replicasetUUID == "" {
+			continue
+		}
+
+		desired, err := topology.GetRoles(sts)
+		if err != nil {
+			continue
+		}
+
+		actual, err := topologyClient.GetReplicasetRolesFromService(replicasetUUID)
+		if err != nil {
+			reqLogger.Error(err, "failed to read current roles for drift check", "StatefulSet.Name", sts.GetName())
+			continue
+		}
+
+		if stringSetsEqual(desired, actual) {
+			continue
+		}
+
+		if err := topologyClient.SetReplicasetRoles(replicasetUUID, desired); err != nil {
+			reqLogger.Error(err, "failed to correct role drift", "StatefulSet.Name", sts.GetName())
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "TopologyDriftCorrectionFailed", "%s: roles drifted to %v, failed to restore %v: %s", sts.GetName(), actual, desired, err.Error())
+			continue
+		}
+
+		corrected = append(corrected, sts.GetName())
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "TopologyDriftCorrected", "%s: roles drifted to %v out-of-band, restored to %v", sts.GetName(), actual, desired)
+	}
+
+	if len(corrected) > 0 {
+		if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.RolesReconciled, tarantooliov1alpha1.ConditionTrue, "DriftCorrected", fmt.Sprintf("corrected role drift on: %v", corrected)); cerr != nil {
+			reqLogger.Error(cerr, "failed to record RolesReconciled condition")
+		}
+	}
+}
+
+// stringSetsEqual reports whether a and b contain the same strings, ignoring order.
+func stringSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+	}
+	for _, n := range counts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	annotationTemplateHash        = "tarantool.io/templateHash"
+	annotationScheduledDelete     = "tarantool.io/scheduledDelete"
+	annotationDrainingPod         = "tarantool.io/upgradeDrainingPod"
+	annotationDrainStartedAt      = "tarantool.io/upgradeDrainStartedAt"
+	annotationPreDrainWeight      = "tarantool.io/preDrainWeight"
+	annotationExpelDrainStartedAt = "tarantool.io/expelDrainStartedAt"
+
+	// expelFinalizer blocks a StatefulSet's pods from actually being removed until
+	// this controller has expelled them from the topology, so a pod deletion request
+	// received mid-drain can't lose the buckets it's still holding.
+	expelFinalizer = "tarantool.io/expel"
+
+	// manualExpelLabel, when set on a Pod (to any non-empty value), requests that the
+	// operator gracefully expel and delete that one instance -- a declarative
+	// alternative to `kubectl delete pod`, which races the pod's removal against
+	// Cartridge's expel_server call instead of waiting for it.
+	manualExpelLabel = "tarantool.io/to-delete"
+
+	defaultDrainTimeout = 5 * time.Minute
+	defaultExpelTimeout = 5 * time.Minute
+)
+
+// reconcileManualExpel expels and deletes every Pod across stsList labeled with
+// manualExpelLabel. It stamps expelFinalizer onto a labeled pod before calling Expel
+// (unless the pod already carries it from a replicaset-wide expel), so a concurrent
+// `kubectl delete pod` can't remove it before expel_server has actually run.
+func (r *ClusterReconciler) reconcileManualExpel(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, stsList *appsv1.StatefulSetList, topologyClient *topology.BuiltInTopologyService) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	for i := range stsList.Items {
+		sts := &stsList.Items[i]
+		if sts.Spec.Replicas == nil {
+			continue
+		}
+
+		for j := 0; j < int(*sts.Spec.Replicas); j++ {
+			pod := &corev1.Pod{}
+			name := types.NamespacedName{Namespace: sts.GetNamespace(), Name: fmt.Sprintf("%s-%d", sts.GetName(), j)}
+			if err := r.Get(ctx, name, pod); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return ctrl.Result{}, err
+			}
+
+			if pod.GetLabels()[manualExpelLabel] == "" {
+				continue
+			}
+
+			if !controllerutil.ContainsFinalizer(pod, expelFinalizer) {
+				if err := kubeutil.UpdateWithRetry(ctx, r.Client, pod, func(o client.Object) error {
+					controllerutil.AddFinalizer(o.(*corev1.Pod), expelFinalizer)
+					return nil
+				}); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+
+			if !tarantool.IsExpelling(pod) {
+				tarantool.MarkExpelling(pod)
+				if err := r.Update(ctx, pod); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+
+			if err := topologyClient.Expel(pod); err != nil && !topology.IsAlreadyExpelled(err) && !topology.IsServerDead(err) {
+				reqLogger.Error(err, "failed to expel pod labeled for manual deletion, will retry", "Pod.Name", pod.GetName())
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "ExpelFailed", "failed to expel %s requested via %s: %s", pod.GetName(), manualExpelLabel, err.Error())
+				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+			}
+
+			if err := kubeutil.UpdateWithRetry(ctx, r.Client, pod, func(o client.Object) error {
+				controllerutil.RemoveFinalizer(o.(*corev1.Pod), expelFinalizer)
+				return nil
+			}); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := kubeutil.DeleteWithRetry(ctx, r.Client, pod); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "PodExpelled", "%s was expelled and deleted after being labeled %s", pod.GetName(), manualExpelLabel)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDraining is the Draining phase of a replicaset's expel workflow: once its
+// tarantool.io/replicaset-weight annotation is "0", vshard is migrating its buckets away.
+// This polls GetServerStat until BucketsCount reaches zero (or Spec.ExpelTimeout elapses),
+// stamping sts and its pods with the expelFinalizer on first entry so a pod deletion
+// request racing the drain blocks instead of losing buckets still held by the replicaset.
+// Reaching zero (or the timeout) advances the replicaset to Expelling by setting
+// tarantool.io/scheduledDelete=1, which reconcileScheduledDelete picks up next reconcile.
+func (r *ClusterReconciler) reconcileDraining(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, sts *appsv1.StatefulSet, topologyClient *topology.BuiltInTopologyService) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(sts, expelFinalizer) {
+		if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+			updated := o.(*appsv1.StatefulSet)
+			controllerutil.AddFinalizer(updated, expelFinalizer)
+			annotations := updated.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[annotationExpelDrainStartedAt] = time.Now().UTC().Format(time.RFC3339)
+			updated.SetAnnotations(annotations)
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.addExpelFinalizerToPods(ctx, sts); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.ExpelSucceeded, tarantooliov1alpha1.ConditionFalse, "Draining", fmt.Sprintf("%s is draining its vshard buckets", sts.GetName())); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+	}
+
+	drained, err := replicasetBucketsDrained(topologyClient, sts.GetName())
+	if err != nil {
+		reqLogger.Error(err, "failed to get server stats while draining for expel", "StatefulSet.Name", sts.GetName())
+	}
+
+	expelTimeout := defaultExpelTimeout
+	if cluster.Spec.ExpelTimeout != nil {
+		expelTimeout = cluster.Spec.ExpelTimeout.Duration
+	}
+
+	timedOut := false
+	if startedAt, err := time.Parse(time.RFC3339, sts.GetAnnotations()[annotationExpelDrainStartedAt]); err == nil {
+		timedOut = time.Since(startedAt) > expelTimeout
+	}
+
+	if !drained && !timedOut {
+		reqLogger.Info("replicaset still has buckets, retry checking on next run", "StatefulSet.Name", sts.GetName())
+		return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+	}
+
+	if !drained && timedOut {
+		reqLogger.Info("expel timeout elapsed with buckets still undrained, expelling anyway", "StatefulSet.Name", sts.GetName())
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "ExpelTimeout", "%s still has undrained buckets after its expel timeout, expelling anyway", sts.GetName())
+	}
+
+	reqLogger.Info("replicaset has migrated all of its buckets away, scheduling for removal", "StatefulSet.Name", sts.GetName())
+	if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+		updated := o.(*appsv1.StatefulSet)
+		annotations := updated.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[annotationScheduledDelete] = "1"
+		updated.SetAnnotations(annotations)
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "RebalanceScheduled", "replicaset %s has drained its buckets, scheduled for removal", sts.GetName())
+
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.ExpelSucceeded, tarantooliov1alpha1.ConditionFalse, "Expelling", fmt.Sprintf("%s is being expelled from the topology", sts.GetName())); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+}
+
+// addExpelFinalizerToPods stamps every existing pod of sts with expelFinalizer, so an
+// in-flight drain blocks a concurrent pod deletion the same way it blocks sts's own.
+func (r *ClusterReconciler) addExpelFinalizerToPods(ctx context.Context, sts *appsv1.StatefulSet) error {
+	if sts.Spec.Replicas == nil {
+		return nil
+	}
+
+	for i := 0; i < int(*sts.Spec.Replicas); i++ {
+		pod := &corev1.Pod{}
+		name := types.NamespacedName{Namespace: sts.GetNamespace(), Name: fmt.Sprintf("%s-%d", sts.GetName(), i)}
+		if err := r.Get(ctx, name, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if controllerutil.ContainsFinalizer(pod, expelFinalizer) {
+			continue
+		}
+
+		if err := kubeutil.UpdateWithRetry(ctx, r.Client, pod, func(o client.Object) error {
+			controllerutil.AddFinalizer(o.(*corev1.Pod), expelFinalizer)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileUpgrades drives the Cluster's rolling-upgrade and scheduled-expel phase:
+// StatefulSets marked tarantool.io/scheduledDelete=1 get every pod expelled and are
+// then deleted once their buckets have drained, and pods whose tarantool.io/templateHash
+// no longer matches their StatefulSet's are replaced one pod per replicaset at a time
+// (with the Parallel strategy, no new drain starts while MaxUnavailable StatefulSets
+// across the Cluster are already draining), draining reads away first unless the
+// strategy is Recreate.
+func (r *ClusterReconciler) reconcileUpgrades(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, stsList *appsv1.StatefulSetList, topologyClient *topology.BuiltInTopologyService) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	strategy := cluster.Spec.UpgradeStrategy
+	strategyType := tarantooliov1alpha1.UpgradeStrategyRollingWithDrain
+	drainTimeout := defaultDrainTimeout
+	maxUnavailable := int32(1)
+
+	if strategy != nil {
+		if strategy.Type != "" {
+			strategyType = strategy.Type
+		}
+		if strategy.DrainTimeout != nil {
+			drainTimeout = strategy.DrainTimeout.Duration
+		}
+		if strategy.MaxUnavailable != nil {
+			maxUnavailable = *strategy.MaxUnavailable
+		}
+	}
+
+	draining := int32(0)
+	for i := range stsList.Items {
+		if stsList.Items[i].GetAnnotations()[annotationDrainingPod] != "" {
+			draining++
+		}
+	}
+
+	for i := range stsList.Items {
+		sts := &stsList.Items[i]
+		annotations := sts.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		if annotations[annotationScheduledDelete] == "1" {
+			result, err := r.reconcileScheduledDelete(ctx, cluster, sts, topologyClient)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if result.Requeue || result.RequeueAfter > 0 {
+				return result, nil
+			}
+			continue
+		}
+
+		if drainingPod := annotations[annotationDrainingPod]; drainingPod != "" {
+			if err := r.continueDraining(ctx, cluster, sts, topologyClient, drainingPod, drainTimeout); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+
+		if strategyType == tarantooliov1alpha1.UpgradeStrategyParallel && draining >= maxUnavailable {
+			continue
+		}
+
+		stalePod, err := r.findStalePod(ctx, sts)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if stalePod == nil {
+			continue
+		}
+
+		if strategyType == tarantooliov1alpha1.UpgradeStrategyRecreate {
+			reqLogger.Info("upgrade: recreating stale pod", "Pod.Name", stalePod.GetName())
+			if err := kubeutil.DeleteWithRetry(ctx, r.Client, stalePod); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+
+		reqLogger.Info("upgrade: draining replicaset before recreating stale pod", "StatefulSet.Name", sts.GetName(), "Pod.Name", stalePod.GetName())
+		drainStartedAt := time.Now().UTC().Format(time.RFC3339)
+		if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+			updated := o.(*appsv1.StatefulSet)
+			updatedAnnotations := updated.GetAnnotations()
+			if updatedAnnotations == nil {
+				updatedAnnotations = map[string]string{}
+			}
+			updatedAnnotations[annotationPreDrainWeight] = updatedAnnotations["tarantool.io/replicaset-weight"]
+			updatedAnnotations[annotationDrainingPod] = stalePod.GetName()
+			updatedAnnotations[annotationDrainStartedAt] = drainStartedAt
+			updated.SetAnnotations(updatedAnnotations)
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		opts, err := editReplicasetWeightOptions(sts, "0")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := topologyClient.EditReplicaset(sts.GetLabels()["tarantool.io/replicaset-uuid"], opts); err != nil {
+			return ctrl.Result{}, err
+		}
+		draining++
+
+		if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.UpgradeProgressed, tarantooliov1alpha1.ConditionFalse, "Draining", fmt.Sprintf("draining %s before recreating it at the current pod template", stalePod.GetName())); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// findStalePod returns the first pod belonging to sts whose tarantool.io/templateHash
+// annotation doesn't match the StatefulSet's, or nil if every pod is current. Pods
+// without the annotation are treated as current, so enabling this feature doesn't
+// force every pre-existing pod through a drain cycle.
+func (r *ClusterReconciler) findStalePod(ctx context.Context, sts *appsv1.StatefulSet) (*corev1.Pod, error) {
+	desiredHash := sts.GetAnnotations()[annotationTemplateHash]
+	if desiredHash == "" || sts.Spec.Replicas == nil {
+		return nil, nil
+	}
+
+	for i := 0; i < int(*sts.Spec.Replicas); i++ {
+		pod := &corev1.Pod{}
+		name := types.NamespacedName{Namespace: sts.GetNamespace(), Name: fmt.Sprintf("%s-%d", sts.GetName(), i)}
+		if err := r.Get(ctx, name, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		podHash := pod.GetAnnotations()[annotationTemplateHash]
+		if podHash != "" && podHash != desiredHash {
+			return pod, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// continueDraining checks on a replicaset that was previously set draining: once its
+// buckets have reached zero (or drainTimeout has elapsed), it expels and deletes the
+// targeted pod, restores the replicaset's weight and clears the draining annotations.
+func (r *ClusterReconciler) continueDraining(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, sts *appsv1.StatefulSet, topologyClient *topology.BuiltInTopologyService, podName string, drainTimeout time.Duration) error {
+	reqLogger := log.FromContext(ctx)
+
+	annotations := sts.GetAnnotations()
+
+	drained, err := replicasetBucketsDrained(topologyClient, sts.GetName())
+	if err != nil {
+		reqLogger.Error(err, "failed to get server stats while draining", "StatefulSet.Name", sts.GetName())
+	}
+
+	timedOut := false
+	if startedAt, err := time.Parse(time.RFC3339, annotations[annotationDrainStartedAt]); err == nil {
+		timedOut = time.Since(startedAt) > drainTimeout
+	}
+
+	if !drained && !timedOut {
+		reqLogger.Info("upgrade: still draining", "StatefulSet.Name", sts.GetName(), "Pod.Name", podName)
+		return nil
+	}
+	if timedOut && !drained {
+		reqLogger.Info("upgrade: drain timed out, expelling anyway", "StatefulSet.Name", sts.GetName(), "Pod.Name", podName)
+	}
+
+	pod := &corev1.Pod{}
+	name := types.NamespacedName{Namespace: sts.GetNamespace(), Name: podName}
+	podErr := r.Get(ctx, name, pod)
+	if podErr != nil && !errors.IsNotFound(podErr) {
+		return podErr
+	}
+	if podErr == nil {
+		tarantool.MarkExpelling(pod)
+		if err := r.Update(ctx, pod); err != nil {
+			return err
+		}
+
+		if err := topologyClient.Expel(pod); err != nil {
+			reqLogger.Error(err, "failed to expel pod, will retry", "Pod.Name", podName)
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "ExpelFailed", "failed to expel %s: %s", podName, err.Error())
+			if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.ExpelSucceeded, tarantooliov1alpha1.ConditionFalse, "ExpelFailed", err.Error()); cerr != nil {
+				reqLogger.Error(cerr, "failed to record ExpelSucceeded condition")
+			}
+			return nil
+		}
+		if err := kubeutil.DeleteWithRetry(ctx, r.Client, pod); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ReplicasetDrained", "%s was expelled and deleted, its StatefulSet will recreate it at the current pod template", podName)
+		if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.ExpelSucceeded, tarantooliov1alpha1.ConditionTrue, "Expelled", fmt.Sprintf("%s was expelled", podName)); err != nil {
+			return err
+		}
+	}
+
+	preDrainWeight := annotations[annotationPreDrainWeight]
+	if preDrainWeight == "" {
+		preDrainWeight = "100"
+	}
+	opts, err := editReplicasetWeightOptions(sts, preDrainWeight)
+	if err != nil {
+		return err
+	}
+	if err := topologyClient.EditReplicaset(sts.GetLabels()["tarantool.io/replicaset-uuid"], opts); err != nil {
+		return err
+	}
+
+	if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+		updated := o.(*appsv1.StatefulSet)
+		updatedAnnotations := updated.GetAnnotations()
+		delete(updatedAnnotations, annotationDrainingPod)
+		delete(updatedAnnotations, annotationDrainStartedAt)
+		delete(updatedAnnotations, annotationPreDrainWeight)
+		updated.SetAnnotations(updatedAnnotations)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return r.setCondition(ctx, cluster, tarantooliov1alpha1.UpgradeProgressed, tarantooliov1alpha1.ConditionTrue, "Recreated", fmt.Sprintf("%s was expelled and deleted, its StatefulSet will recreate it at the current pod template", podName))
+}
+
+// reconcileScheduledDelete is the Expelling/Removed phase of a replicaset's expel
+// workflow, reached once reconcileDraining has set sts's tarantool.io/scheduledDelete
+// annotation. It expels each pod still carrying expelFinalizer -- mapping
+// topology.IsAlreadyExpelled the same way Join maps IsAlreadyJoined -- then clears the
+// finalizer and deletes the pod, and once every pod is gone, removes sts's own finalizer
+// and deletes it.
+func (r *ClusterReconciler) reconcileScheduledDelete(ctx context.Context, cluster *tarantooliov1alpha1.Cluster, sts *appsv1.StatefulSet, topologyClient *topology.BuiltInTopologyService) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	if sts.Spec.Replicas != nil {
+		for i := 0; i < int(*sts.Spec.Replicas); i++ {
+			pod := &corev1.Pod{}
+			name := types.NamespacedName{Namespace: sts.GetNamespace(), Name: fmt.Sprintf("%s-%d", sts.GetName(), i)}
+			if err := r.Get(ctx, name, pod); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return ctrl.Result{}, err
+			}
+
+			if !controllerutil.ContainsFinalizer(pod, expelFinalizer) {
+				continue
+			}
+
+			tarantool.MarkExpelling(pod)
+			if err := r.Update(ctx, pod); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if err := topologyClient.Expel(pod); err != nil && !topology.IsAlreadyExpelled(err) && !topology.IsServerDead(err) {
+				reqLogger.Error(err, "failed to expel pod scheduled for deletion, will retry", "Pod.Name", pod.GetName())
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "ExpelFailed", "failed to expel %s scheduled for removal: %s", pod.GetName(), err.Error())
+				if cerr := r.setCondition(ctx, cluster, tarantooliov1alpha1.ExpelSucceeded, tarantooliov1alpha1.ConditionFalse, "ExpelFailed", err.Error()); cerr != nil {
+					reqLogger.Error(cerr, "failed to record ExpelSucceeded condition")
+				}
+				return ctrl.Result{RequeueAfter: time.Duration(5 * time.Second)}, nil
+			}
+
+			if err := kubeutil.UpdateWithRetry(ctx, r.Client, pod, func(o client.Object) error {
+				controllerutil.RemoveFinalizer(o.(*corev1.Pod), expelFinalizer)
+				return nil
+			}); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if err := kubeutil.DeleteWithRetry(ctx, r.Client, pod); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if err := deleteVolumeClaims(ctx, r.Client, sts, pod.GetName()); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	reqLogger.Info("upgrade: every pod expelled, deleting StatefulSet", "StatefulSet.Name", sts.GetName())
+	if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+		controllerutil.RemoveFinalizer(o.(*appsv1.StatefulSet), expelFinalizer)
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := kubeutil.DeleteWithRetry(ctx, r.Client, sts); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ReplicasetExpelled", "%s was expelled and removed", sts.GetName())
+
+	if err := r.setCondition(ctx, cluster, tarantooliov1alpha1.ExpelSucceeded, tarantooliov1alpha1.ConditionTrue, "Removed", fmt.Sprintf("%s was expelled", sts.GetName())); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.setCondition(ctx, cluster, tarantooliov1alpha1.UpgradeProgressed, tarantooliov1alpha1.ConditionTrue, "Expelled", fmt.Sprintf("%s was expelled and deleted", sts.GetName()))
+}
+
+// deleteVolumeClaims deletes the PersistentVolumeClaims Kubernetes created for podName
+// from sts's volumeClaimTemplates, following the standard <claimTemplateName>-<podName>
+// naming convention, so a StatefulSet downscale doesn't leave its storage orphaned
+// behind it. Tolerates a claim already being gone.
+func deleteVolumeClaims(ctx context.Context, c client.Client, sts *appsv1.StatefulSet, podName string) error {
+	for _, claimTemplate := range sts.Spec.VolumeClaimTemplates {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: sts.GetNamespace(),
+				Name:      fmt.Sprintf("%s-%s", claimTemplate.GetName(), podName),
+			},
+		}
+		if err := kubeutil.DeleteWithRetry(ctx, c, pvc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replicasetBucketsDrained reports whether a replicaset's StatefulSet has zero
+// buckets left, per the latest GetServerStat snapshot.
+func replicasetBucketsDrained(topologyClient *topology.BuiltInTopologyService, stsName string) (bool, error) {
+	data, err := topologyClient.GetServerStat()
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	for _, stat := range data.Stats {
+		if !strings.HasPrefix(stat.URI, stsName) {
+			continue
+		}
+		found = true
+		if stat.Statistics.BucketsCount != 0 {
+			return false, nil
+		}
+	}
+
+	return found, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("cluster-controller")
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&tarantooliov1alpha1.Cluster{}).
+		Watches(&source.Kind{Type: &tarantooliov1alpha1.Cluster{}}, &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			if a.GetLabels() == nil {
+				return []ctrl.Request{}
+			}
+			return []ctrl.Request{
+				{NamespacedName: types.NamespacedName{
+					Namespace: a.GetNamespace(),
+					Name:      a.GetLabels()["tarantool.io/cluster-id"],
+				}},
+			}
+		}), builder.WithPredicates(ManagedPodPredicate(r.WatchNamespace, r.ClusterLabelSelector))).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			clusterList := &tarantooliov1alpha1.ClusterList{}
+			if err := mgr.GetClient().List(context.Background(), clusterList, &client.ListOptions{Namespace: a.GetNamespace()}); err != nil {
+				return []reconcile.Request{}
+			}
+
+			var requests []reconcile.Request
+			for i := range clusterList.Items {
+				if clusterReferencesSecret(&clusterList.Items[i], a.GetName()) {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{Namespace: a.GetNamespace(), Name: clusterList.Items[i].GetName()},
+					})
+				}
 			}
+			return requests
 		})).
 		Complete(r)
 }
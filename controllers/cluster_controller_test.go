@@ -9,8 +9,10 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/tarantool/tarantool-operator/controllers/kubeutil"
 	helpers "github.com/tarantool/tarantool-operator/test/helpers"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -97,51 +99,58 @@ var _ = Describe("cluster_controller unit testing", func() {
 		Context("manage cluster leader: tarantool instance accepting admin requests", func() {
 			It("change the leader if the previous one does not exist", func() {
 				By("get the chosen leader")
-				ep := corev1.Endpoints{}
+				lease := coordinationv1.Lease{}
 				Eventually(
 					func() bool {
-						err := k8sClient.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: namespace}, &ep)
+						err := k8sClient.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: namespace}, &lease)
 						if err != nil {
 							return false
 						}
 
-						if ep.GetAnnotations()["tarantool.io/leader"] != "" {
-							return true
-						}
-
-						return false
+						return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != ""
 					},
 					2*time.Minute,
 					500*time.Millisecond,
 				).Should(BeTrue())
 
 				By("save old leader")
-				oldLeader := ep.GetAnnotations()["tarantool.io/leader"]
+				oldLeader := *lease.Spec.HolderIdentity
 
 				By("set all new IP addresses")
-				ep.Subsets = []corev1.EndpointSubset{
-					{
-						Addresses: []corev1.EndpointAddress{
-							{IP: "4.4.4.4"},
-							{IP: "5.5.5.5"},
-							{IP: "6.6.6.6"},
+				ep := corev1.Endpoints{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: namespace}, &ep)).
+					NotTo(HaveOccurred(), "failed to get cluster endpoints")
+				Expect(kubeutil.UpdateWithRetry(ctx, k8sClient, &ep, func(o client.Object) error {
+					o.(*corev1.Endpoints).Subsets = []corev1.EndpointSubset{
+						{
+							Addresses: []corev1.EndpointAddress{
+								{IP: "4.4.4.4"},
+								{IP: "5.5.5.5"},
+								{IP: "6.6.6.6"},
+							},
 						},
-					},
-				}
-				Expect(k8sClient.Update(ctx, &ep)).NotTo(HaveOccurred(), "failed to update cluster endpoints")
+					}
+					return nil
+				})).NotTo(HaveOccurred(), "failed to update cluster endpoints")
+
+				By("make the lease look expired so a new leader is elected")
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: clusterId, Namespace: namespace}, &lease)).
+					NotTo(HaveOccurred(), "failed to get cluster leader lease")
+				Expect(kubeutil.UpdateWithRetry(ctx, k8sClient, &lease, func(o client.Object) error {
+					past := metav1.NewMicroTime(time.Now().Add(-1 * time.Hour))
+					o.(*coordinationv1.Lease).Spec.RenewTime = &past
+					return nil
+				})).NotTo(HaveOccurred(), "failed to expire cluster leader lease")
 
 				By("check that the leader has changed")
 				Eventually(
 					func() bool {
-						err := k8sClient.Get(ctx, client.ObjectKey{Name: clusterId, Namespace: namespace}, &ep)
+						err := k8sClient.Get(ctx, client.ObjectKey{Name: clusterId, Namespace: namespace}, &lease)
 						if err != nil {
 							return false
 						}
 
-						if ep.GetAnnotations()["tarantool.io/leader"] != oldLeader {
-							return true
-						}
-						return false
+						return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != oldLeader
 					},
 					2*time.Minute,
 					500*time.Millisecond,
@@ -151,60 +160,60 @@ var _ = Describe("cluster_controller unit testing", func() {
 	})
 
 	Describe("cluster_controller unit testing functions", func() {
-		Describe("function IsLeaderExists must check for existence of leader in annotation of cluster Endpoints", func() {
+		Describe("function IsLeaderExists must check for an unexpired holder on the leader Lease", func() {
 			Context("positive cases (leader exist)", func() {
-				It("should return True if leader assigned and exist", func() {
-					leaderIP := "1.1.1.1"
+				It("should return True if the lease has a holder that hasn't expired", func() {
+					holder := "1.1.1.1:8081"
+					durationSeconds := int32(15)
+					renewTime := metav1.NewMicroTime(time.Now())
 
-					ep := &corev1.Endpoints{
+					lease := &coordinationv1.Lease{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      "name",
 							Namespace: "namespace",
-							Annotations: map[string]string{
-								"tarantool.io/leader": fmt.Sprintf("%s:8081", leaderIP),
-							},
 						},
-						Subsets: []corev1.EndpointSubset{
-							{
-								Addresses: []corev1.EndpointAddress{
-									{IP: leaderIP},
-								},
-							},
+						Spec: coordinationv1.LeaseSpec{
+							HolderIdentity:       &holder,
+							LeaseDurationSeconds: &durationSeconds,
+							RenewTime:            &renewTime,
 						},
 					}
-					Expect(IsLeaderExists(ep)).To(BeTrue())
+					Expect(IsLeaderExists(lease)).To(BeTrue())
 				})
 			})
 
 			Context("negative cases (leader does not exist)", func() {
-				It("should return False if leader not assigned", func() {
-					ep := &corev1.Endpoints{
+				It("should return False if the lease is nil", func() {
+					Expect(IsLeaderExists(nil)).To(BeFalse())
+				})
+
+				It("should return False if no holder is assigned", func() {
+					lease := &coordinationv1.Lease{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      "name",
 							Namespace: "namespace",
 						},
 					}
-					Expect(IsLeaderExists(ep)).To(BeFalse())
+					Expect(IsLeaderExists(lease)).To(BeFalse())
 				})
 
-				It("should return False if leader assigned, but IP not exists", func() {
-					ep := &corev1.Endpoints{
+				It("should return False if the holder's lease has expired", func() {
+					holder := "6.6.6.6:8081"
+					durationSeconds := int32(15)
+					renewTime := metav1.NewMicroTime(time.Now().Add(-1 * time.Hour))
+
+					lease := &coordinationv1.Lease{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      "name",
 							Namespace: "namespace",
-							Annotations: map[string]string{
-								"tarantool.io/leader": "6.6.6.6:8081",
-							},
 						},
-						Subsets: []corev1.EndpointSubset{
-							{
-								Addresses: []corev1.EndpointAddress{
-									{IP: "0.0.0.0"},
-								},
-							},
+						Spec: coordinationv1.LeaseSpec{
+							HolderIdentity:       &holder,
+							LeaseDurationSeconds: &durationSeconds,
+							RenewTime:            &renewTime,
 						},
 					}
-					Expect(IsLeaderExists(ep)).To(BeFalse())
+					Expect(IsLeaderExists(lease)).To(BeFalse())
 				})
 			})
 		})
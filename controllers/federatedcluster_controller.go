@@ -0,0 +1,204 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	"github.com/tarantool/tarantool-operator/controllers/federation"
+)
+
+// FederatedClusterReconciler reconciles a FederatedCluster object.
+//
+// A placement with a KubeconfigSecretRef is fanned out for real: Clusters (a
+// federation.ClusterProvider) connects a cluster.Cluster for it from the kubeconfig
+// Secret, and this reconciler creates/reads the placement's Cluster through that member
+// cluster's own client.Client. Clusters' OnConnect hook additionally starts a second
+// controller that reconciles it there with ClusterReconciler. A placement without a
+// KubeconfigSecretRef keeps the original stand-in behavior -- its Cluster is reconciled
+// locally, namespaced by placement name.
+//
+// Cross-cluster objects can't carry an OwnerReference (UIDs don't resolve across API
+// servers), so a member cluster's placement Cluster is deleted explicitly rather than
+// garbage-collected; FederatedCluster deletion handling for that case isn't wired up
+// yet. Likewise Owns(&Cluster{}) below only catches local-placement changes -- a
+// member cluster's Cluster status changes don't yet wake this Reconcile, so
+// PlacementStatuses for fanned-out placements lags until the next FederatedCluster
+// change or resync.
+type FederatedClusterReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Manager ctrl.Manager
+
+	// Clusters resolves a placement's ClusterName to its member cluster.Cluster
+	// handle. Built by SetupWithManager; tests may substitute their own ClusterProvider.
+	Clusters federation.ClusterProvider
+}
+
+//+kubebuilder:rbac:groups=tarantool.io,resources=federatedclusters,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=tarantool.io,resources=federatedclusters/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=tarantool.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
+
+// placementClusterName returns the name of the local Cluster standing in for a
+// placement that has no KubeconfigSecretRef, i.e. no member cluster to fan out to.
+func placementClusterName(fedCluster *tarantooliov1alpha1.FederatedCluster, placement tarantooliov1alpha1.ClusterPlacement) string {
+	return fmt.Sprintf("%s-%s", fedCluster.GetName(), placement.ClusterName)
+}
+
+// startMemberClusterReconciler is a federation.ClusterProvider.OnConnect hook: it adds
+// a ClusterReconciler controller watching c's Clusters, so a placement fanned out to a
+// member cluster gets the same StatefulSet/topology reconciliation as a local Cluster.
+func (r *FederatedClusterReconciler) startMemberClusterReconciler(c cluster.Cluster, name string) error {
+	memberReconciler := &ClusterReconciler{
+		Client:   c.GetClient(),
+		Scheme:   r.Scheme,
+		Recorder: c.GetEventRecorderFor("tarantool-operator"),
+	}
+	ctl, err := controller.NewUnmanaged(fmt.Sprintf("cluster-%s", name), r.Manager, controller.Options{Reconciler: memberReconciler})
+	if err != nil {
+		return fmt.Errorf("building Cluster controller for member cluster %q: %w", name, err)
+	}
+	if err := ctl.Watch(source.NewKindWithCache(&tarantooliov1alpha1.Cluster{}, c.GetCache()), &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("watching Clusters on member cluster %q: %w", name, err)
+	}
+	if err := r.Manager.Add(ctl); err != nil {
+		return fmt.Errorf("starting Cluster controller for member cluster %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// Reconcile ensures a Cluster exists for each Placement -- in its member cluster when
+// the placement has a KubeconfigSecretRef, locally otherwise -- and aggregates each
+// one's Status.Conditions back onto the FederatedCluster.
+func (r *FederatedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+	reqLogger.Info("Reconciling FederatedCluster")
+
+	fedCluster := &tarantooliov1alpha1.FederatedCluster{}
+	if err := r.Get(ctx, req.NamespacedName, fedCluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	placementStatuses := map[string][]tarantooliov1alpha1.ClusterCondition{}
+
+	for _, placement := range fedCluster.Spec.Placements {
+		placementClient := r.Client
+		name := types.NamespacedName{Namespace: fedCluster.GetNamespace(), Name: placementClusterName(fedCluster, placement)}
+		setOwnerRef := true
+
+		if placement.KubeconfigSecretRef != nil {
+			memberCluster, err := r.Clusters.Get(ctx, placement.ClusterName, placement.KubeconfigSecretRef, fedCluster.GetNamespace())
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			placementClient = memberCluster.GetClient()
+			name = types.NamespacedName{Namespace: fedCluster.GetNamespace(), Name: fedCluster.GetName()}
+			setOwnerRef = false
+		}
+
+		cluster := &tarantooliov1alpha1.Cluster{}
+		created := false
+		if err := placementClient.Get(ctx, name, cluster); err != nil {
+			if !errors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+
+			cluster.Name = name.Name
+			cluster.Namespace = name.Namespace
+			cluster.Spec = fedCluster.Spec.Template
+			if setOwnerRef {
+				if err := controllerutil.SetControllerReference(fedCluster, cluster, r.Scheme); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			if err := placementClient.Create(ctx, cluster); err != nil {
+				return ctrl.Result{}, err
+			}
+			created = true
+			reqLogger.Info("created placement Cluster", "Placement.ClusterName", placement.ClusterName, "Cluster.Name", cluster.GetName())
+		}
+
+		federation := &tarantooliov1alpha1.FederationStatus{
+			FederatedClusterName: fedCluster.GetName(),
+			MemberClusterName:    placement.ClusterName,
+		}
+		if created || cluster.Status.Federation == nil || *cluster.Status.Federation != *federation {
+			cluster.Status.Federation = federation
+			if err := placementClient.Status().Update(ctx, cluster); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		placementStatuses[placement.ClusterName] = cluster.Status.Conditions
+	}
+
+	fedCluster.Status.ObservedGeneration = fedCluster.GetGeneration()
+	fedCluster.Status.PlacementStatuses = placementStatuses
+	if err := r.Status().Update(ctx, fedCluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FederatedClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Manager = mgr
+	if r.Clusters == nil {
+		r.Clusters = &federation.MemberClusterProvider{
+			Client:    mgr.GetClient(),
+			Manager:   mgr,
+			Scheme:    r.Scheme,
+			OnConnect: r.startMemberClusterReconciler,
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&tarantooliov1alpha1.FederatedCluster{}).
+		Owns(&tarantooliov1alpha1.Cluster{}).
+		Complete(r)
+}
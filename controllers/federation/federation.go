@@ -0,0 +1,117 @@
+// Package federation builds *rest.Config values for member clusters named by a
+// FederatedCluster placement, so the FederatedCluster controller can hand them to
+// controller-runtime's cluster.New and get back a client.Client scoped to that member
+// cluster. ClusterProvider and MemberClusterProvider then wrap that connection step
+// with caching, so a placement's member cluster is dialed and started at most once.
+package federation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// kubeconfigSecretKey is the Secret data key a placement's KubeconfigSecretRef is
+// expected to hold the member cluster's kubeconfig under.
+const kubeconfigSecretKey = "kubeconfig"
+
+// ClusterFromSecret reads the kubeconfig Secret named by ref (in defaultNamespace,
+// unless ref.Namespace is set) and builds the *rest.Config it describes. c is the
+// client used to fetch the Secret itself, i.e. the local/hub cluster's client, not the
+// member cluster's.
+func ClusterFromSecret(ctx context.Context, c client.Client, ref *corev1.SecretReference, defaultNamespace string) (*rest.Config, error) {
+	namespace := defaultNamespace
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	if err := c.Get(ctx, name, secret); err != nil {
+		return nil, fmt.Errorf("getting kubeconfig secret %s/%s: %w", namespace, ref.Name, err)
+	}
+
+	kubeconfig, ok := secret.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", namespace, ref.Name, kubeconfigSecretKey)
+	}
+
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig from secret %s/%s: %w", namespace, ref.Name, err)
+	}
+
+	return cfg, nil
+}
+
+// ClusterProvider yields the controller-runtime cluster.Cluster handle registered
+// under name, connecting to and starting it on first use. Callers resolve a
+// placement/request to a member cluster through this interface rather than dialing
+// cluster.New themselves, so the connection (and whatever else OnConnect wires up) is
+// only ever done once per name.
+type ClusterProvider interface {
+	// Get returns the named member cluster, connecting it via secretRef (a kubeconfig
+	// Secret reference, resolved relative to defaultNamespace) if it isn't already
+	// cached.
+	Get(ctx context.Context, name string, secretRef *corev1.SecretReference, defaultNamespace string) (cluster.Cluster, error)
+}
+
+// MemberClusterProvider is the ClusterProvider FederatedClusterReconciler uses: it
+// resolves a kubeconfig Secret via ClusterFromSecret, connects with cluster.New, and
+// registers the result with Manager so it's started and cached alongside every other
+// controller-runtime source. OnConnect, if set, runs once per newly connected member --
+// FederatedClusterReconciler uses it to start a companion ClusterReconciler against the
+// new member's cache.
+type MemberClusterProvider struct {
+	client.Client
+	Manager   ctrl.Manager
+	Scheme    *runtime.Scheme
+	OnConnect func(c cluster.Cluster, name string) error
+
+	mu      sync.Mutex
+	members map[string]cluster.Cluster
+}
+
+// Get implements ClusterProvider.
+func (p *MemberClusterProvider) Get(ctx context.Context, name string, secretRef *corev1.SecretReference, defaultNamespace string) (cluster.Cluster, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.members == nil {
+		p.members = map[string]cluster.Cluster{}
+	}
+	if c, ok := p.members[name]; ok {
+		return c, nil
+	}
+
+	cfg, err := ClusterFromSecret(ctx, p.Client, secretRef, defaultNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("resolving member cluster %q: %w", name, err)
+	}
+
+	c, err := cluster.New(cfg, func(o *cluster.Options) { o.Scheme = p.Scheme })
+	if err != nil {
+		return nil, fmt.Errorf("connecting to member cluster %q: %w", name, err)
+	}
+	if err := p.Manager.Add(c); err != nil {
+		return nil, fmt.Errorf("starting member cluster %q: %w", name, err)
+	}
+
+	if p.OnConnect != nil {
+		if err := p.OnConnect(c, name); err != nil {
+			return nil, fmt.Errorf("setting up member cluster %q: %w", name, err)
+		}
+	}
+
+	p.members[name] = c
+	return c, nil
+}
@@ -0,0 +1,86 @@
+// Package kubeutil wraps common reconciler writes with client-go's conflict-retry
+// logic, so a 409 from a concurrent reconcile or another controller doesn't fail the
+// whole Reconcile call and fall back to a blind requeue.
+package kubeutil
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateWithRetry applies mutate to obj and updates it, retrying on conflict. On each
+// conflict, obj is re-fetched from the API server before mutate is called again, so
+// mutate should be idempotent and only set fields relative to obj's current state.
+// obj holds the result of the last attempt once UpdateWithRetry returns, successfully
+// or not.
+func UpdateWithRetry(ctx context.Context, c client.Client, obj client.Object, mutate func(client.Object) error) error {
+	key := client.ObjectKeyFromObject(obj)
+
+	first := true
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if !first {
+			if err := c.Get(ctx, key, obj); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		if err := mutate(obj); err != nil {
+			return err
+		}
+		return c.Update(ctx, obj)
+	})
+}
+
+// PatchWithRetry applies mutate to obj and issues a merge patch against the state obj
+// was last read in, retrying on conflict. Unlike UpdateWithRetry, each retry only
+// re-fetches obj to compute the next patch base; it never loses a concurrent writer's
+// unrelated fields.
+func PatchWithRetry(ctx context.Context, c client.Client, obj client.Object, mutate func(client.Object) error) error {
+	key := client.ObjectKeyFromObject(obj)
+
+	first := true
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if !first {
+			if err := c.Get(ctx, key, obj); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		base := obj.DeepCopyObject().(client.Object)
+		if err := mutate(obj); err != nil {
+			return err
+		}
+		return c.Patch(ctx, obj, client.MergeFrom(base))
+	})
+}
+
+// CreateOrGetWithRetry creates obj, retrying on conflict; if obj already exists by the
+// time the create lands, it fetches the existing object into obj instead of failing.
+func CreateOrGetWithRetry(ctx context.Context, c client.Client, obj client.Object) error {
+	key := client.ObjectKeyFromObject(obj)
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := c.Create(ctx, obj)
+		if apierrors.IsAlreadyExists(err) {
+			return c.Get(ctx, key, obj)
+		}
+		return err
+	})
+}
+
+// DeleteWithRetry deletes obj, retrying on conflict. A NotFound error is treated as
+// success, since the caller's desired end state is already reached.
+func DeleteWithRetry(ctx context.Context, c client.Client, obj client.Object) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := c.Delete(ctx, obj)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,157 @@
+package kubeutil
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// conflictingClient wraps a client.Client and fails the first conflictsLeft calls to
+// the write method under test with a Conflict error, so tests can prove a helper
+// actually retries instead of just happening to succeed first try.
+type conflictingClient struct {
+	client.Client
+	conflictsLeft int
+}
+
+func (c *conflictingClient) conflict() error {
+	if c.conflictsLeft <= 0 {
+		return nil
+	}
+	c.conflictsLeft--
+	return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test", nil)
+}
+
+func (c *conflictingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.conflict(); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *conflictingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.conflict(); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *conflictingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.conflict(); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *conflictingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.conflict(); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func newConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Data:       map[string]string{"k": "v"},
+	}
+}
+
+func TestUpdateWithRetry_ConvergesAfterConflicts(t *testing.T) {
+	cm := newConfigMap()
+	backing := fake.NewClientBuilder().WithObjects(cm).Build()
+	c := &conflictingClient{Client: backing, conflictsLeft: 2}
+
+	obj := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(cm), obj); err != nil {
+		t.Fatalf("unexpected error getting seed object: %s", err)
+	}
+
+	err := UpdateWithRetry(context.Background(), c, obj, func(o client.Object) error {
+		o.(*corev1.ConfigMap).Data["k"] = "updated"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := &corev1.ConfigMap{}
+	if err := backing.Get(context.Background(), client.ObjectKeyFromObject(cm), got); err != nil {
+		t.Fatalf("unexpected error re-fetching object: %s", err)
+	}
+	if got.Data["k"] != "updated" {
+		t.Fatalf("expected data to be updated, got %q", got.Data["k"])
+	}
+}
+
+func TestPatchWithRetry_ConvergesAfterConflicts(t *testing.T) {
+	cm := newConfigMap()
+	backing := fake.NewClientBuilder().WithObjects(cm).Build()
+	c := &conflictingClient{Client: backing, conflictsLeft: 1}
+
+	obj := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(cm), obj); err != nil {
+		t.Fatalf("unexpected error getting seed object: %s", err)
+	}
+
+	err := PatchWithRetry(context.Background(), c, obj, func(o client.Object) error {
+		o.(*corev1.ConfigMap).Data["k"] = "patched"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := &corev1.ConfigMap{}
+	if err := backing.Get(context.Background(), client.ObjectKeyFromObject(cm), got); err != nil {
+		t.Fatalf("unexpected error re-fetching object: %s", err)
+	}
+	if got.Data["k"] != "patched" {
+		t.Fatalf("expected data to be patched, got %q", got.Data["k"])
+	}
+}
+
+func TestCreateOrGetWithRetry_ReturnsExistingOnAlreadyExists(t *testing.T) {
+	cm := newConfigMap()
+	backing := fake.NewClientBuilder().WithObjects(cm).Build()
+	c := &conflictingClient{Client: backing}
+
+	obj := newConfigMap()
+	if err := CreateOrGetWithRetry(context.Background(), c, obj); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if obj.Data["k"] != "v" {
+		t.Fatalf("expected obj to be populated from the existing object, got %q", obj.Data["k"])
+	}
+}
+
+func TestDeleteWithRetry_ConvergesAfterConflicts(t *testing.T) {
+	cm := newConfigMap()
+	backing := fake.NewClientBuilder().WithObjects(cm).Build()
+	c := &conflictingClient{Client: backing, conflictsLeft: 2}
+
+	if err := DeleteWithRetry(context.Background(), c, cm); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := backing.Get(context.Background(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected object to be deleted, got err: %v", err)
+	}
+}
+
+func TestDeleteWithRetry_NotFoundIsNotAnError(t *testing.T) {
+	backing := fake.NewClientBuilder().Build()
+	c := &conflictingClient{Client: backing}
+
+	cm := newConfigMap()
+	if err := DeleteWithRetry(context.Background(), c, cm); err != nil {
+		t.Fatalf("expected NotFound to be treated as success, got: %s", err)
+	}
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	"github.com/tarantool/tarantool-operator/controllers/topology"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+// nopListerWatcher never lists or watches anything; newTestRoleIndexForCandidates
+// seeds the informer's store directly and never calls Run, so it's only here to
+// satisfy the constructor.
+type nopListerWatcher struct{}
+
+func (nopListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	return &corev1.PodList{}, nil
+}
+
+func (nopListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	return watch.NewEmptyWatch(), nil
+}
+
+func newTestRoleIndexForCandidates(t *testing.T, pods ...*corev1.Pod) *topology.RoleIndex {
+	t.Helper()
+
+	informer := cache.NewSharedIndexInformer(nopListerWatcher{}, &corev1.Pod{}, 0, cache.Indexers{})
+	idx, err := topology.NewRoleIndex(informer)
+	if err != nil {
+		t.Fatalf("unexpected error building RoleIndex: %s", err)
+	}
+
+	for _, pod := range pods {
+		if err := informer.GetStore().Add(pod); err != nil {
+			t.Fatalf("unexpected error seeding store: %s", err)
+		}
+	}
+
+	return idx
+}
+
+func newLeaderCandidatesScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+	_ = tarantooliov1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func TestBuildLeaderCandidates_FromIPv6OnlyEndpointSlice(t *testing.T) {
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-ipv6",
+			Namespace: "default",
+			Labels:    map[string]string{endpointSliceServiceLabel: "test"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv6,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"fe80::1"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)}},
+		},
+	}
+
+	r := &ClusterReconciler{Client: fake.NewClientBuilder().WithScheme(newLeaderCandidatesScheme()).WithObjects(slice).Build()}
+
+	candidates := r.buildLeaderCandidates(context.TODO(), cluster, &corev1.Endpoints{})
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 candidate, got %d", len(candidates))
+	}
+	if candidates[0].Address != "[fe80::1]:8081" {
+		t.Fatalf("expected bracketed IPv6 candidate address, got %s", candidates[0].Address)
+	}
+}
+
+func TestBuildLeaderCandidates_MixedFamilyEndpointSlices(t *testing.T) {
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	v4 := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-ipv4",
+			Namespace: "default",
+			Labels:    map[string]string{endpointSliceServiceLabel: "test"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)}},
+		},
+	}
+	v6 := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-ipv6",
+			Namespace: "default",
+			Labels:    map[string]string{endpointSliceServiceLabel: "test"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv6,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"fe80::1"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)}},
+			{Addresses: []string{"fe80::2"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(false)}},
+		},
+	}
+
+	r := &ClusterReconciler{Client: fake.NewClientBuilder().WithScheme(newLeaderCandidatesScheme()).WithObjects(v4, v6).Build()}
+
+	candidates := r.buildLeaderCandidates(context.TODO(), cluster, &corev1.Endpoints{})
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 ready candidates across both slices, got %d: %+v", len(candidates), candidates)
+	}
+
+	addresses := map[string]bool{}
+	for _, c := range candidates {
+		addresses[c.Address] = true
+	}
+	if !addresses["10.0.0.1:8081"] || !addresses["[fe80::1]:8081"] {
+		t.Fatalf("expected candidates for both IPv4 and IPv6 ready endpoints, got %+v", addresses)
+	}
+	if addresses["[fe80::2]:8081"] {
+		t.Fatalf("did not expect a candidate for the not-ready endpoint, got %+v", addresses)
+	}
+}
+
+func TestBuildLeaderCandidates_FallsBackToEndpointsWithoutSlices(t *testing.T) {
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	ep := &corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{
+			{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.9"}}},
+		},
+	}
+
+	r := &ClusterReconciler{Client: fake.NewClientBuilder().WithScheme(newLeaderCandidatesScheme()).Build()}
+
+	candidates := r.buildLeaderCandidates(context.TODO(), cluster, ep)
+	if len(candidates) != 1 || candidates[0].Address != "10.0.0.9:8081" {
+		t.Fatalf("expected the legacy Endpoints-derived candidate, got %+v", candidates)
+	}
+}
+
+func TestBuildLeaderCandidates_RoleIndexUsesPodIPsWhenPodIPUnset(t *testing.T) {
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-0", Namespace: "default", Labels: map[string]string{"tarantool.io/cluster-id": "test"}},
+		Status: corev1.PodStatus{
+			PodIPs: []corev1.PodIP{{IP: "fe80::1"}, {IP: "10.0.0.1"}},
+		},
+	}
+
+	r := &ClusterReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(newLeaderCandidatesScheme()).Build(),
+		RoleIndex: newTestRoleIndexForCandidates(t, pod),
+	}
+
+	candidates := r.buildLeaderCandidates(context.TODO(), cluster, &corev1.Endpoints{})
+	if len(candidates) != 1 || candidates[0].Address != "[fe80::1]:8081" {
+		t.Fatalf("expected a candidate built from PodIPs[0], got %+v", candidates)
+	}
+}
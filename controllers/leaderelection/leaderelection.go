@@ -0,0 +1,125 @@
+// Package leaderelection picks which candidate pod should be recorded as a
+// Cluster's leader, so ClusterReconciler isn't stuck assuming the first endpoint
+// address is always healthy and reachable.
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+)
+
+// ErrNoCandidates is returned when no candidate is eligible to become leader.
+var ErrNoCandidates = errors.New("no leader candidates available")
+
+// Candidate is a ready endpoint address eligible to become cluster leader.
+type Candidate struct {
+	// Address is the host:port the leader annotation records, e.g. "10.0.0.1:8081".
+	Address string
+	// Pod is the candidate's backing Pod, when it could be resolved from the
+	// Endpoint's TargetRef.
+	Pod *corev1.Pod
+}
+
+// LeaderElector picks which candidate should become leader.
+type LeaderElector interface {
+	Elect(ctx context.Context, candidates []Candidate) (*Candidate, error)
+}
+
+// ForStrategy returns the LeaderElector for a Cluster's configured strategy,
+// defaulting to FirstReady when none is set.
+func ForStrategy(strategy tarantooliov1alpha1.LeaderElectionStrategy) LeaderElector {
+	switch strategy {
+	case tarantooliov1alpha1.LeaderElectionLowestUUID:
+		return LowestUUID{}
+	case tarantooliov1alpha1.LeaderElectionHealthProbe:
+		return HealthProbe{}
+	default:
+		return FirstReady{}
+	}
+}
+
+// FirstReady picks the first ready candidate, preserving the operator's original
+// "first IP wins" behavior.
+type FirstReady struct{}
+
+// Elect implements LeaderElector.
+func (FirstReady) Elect(ctx context.Context, candidates []Candidate) (*Candidate, error) {
+	if len(candidates) == 0 {
+		return nil, ErrNoCandidates
+	}
+	return &candidates[0], nil
+}
+
+// LowestUUID picks the ready candidate with the lexicographically smallest
+// tarantool.io/instance-uuid label, so the same pod keeps winning across reconciles
+// regardless of how the Endpoint's address list happens to be ordered.
+type LowestUUID struct{}
+
+// Elect implements LeaderElector.
+func (LowestUUID) Elect(ctx context.Context, candidates []Candidate) (*Candidate, error) {
+	var best *Candidate
+
+	for i := range candidates {
+		c := &candidates[i]
+		if c.Pod == nil {
+			continue
+		}
+
+		uuid, ok := c.Pod.GetLabels()["tarantool.io/instance-uuid"]
+		if !ok {
+			continue
+		}
+
+		if best == nil || uuid < best.Pod.GetLabels()["tarantool.io/instance-uuid"] {
+			best = c
+		}
+	}
+
+	if best == nil {
+		return nil, ErrNoCandidates
+	}
+	return best, nil
+}
+
+// HealthProbe picks the first ready candidate that answers a GET against its
+// Cartridge admin endpoint, so a pod that passed kubelet's readiness probe but
+// can't actually serve topology requests is skipped.
+type HealthProbe struct {
+	// HTTPClient is used to probe candidates. Defaults to a 3s-timeout client.
+	HTTPClient *http.Client
+}
+
+// Elect implements LeaderElector.
+func (h HealthProbe) Elect(ctx context.Context, candidates []Candidate) (*Candidate, error) {
+	client := h.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 3 * time.Second}
+	}
+
+	for i := range candidates {
+		url := fmt.Sprintf("http://%s/admin/api", candidates[i].Address)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			continue
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < http.StatusInternalServerError {
+			return &candidates[i], nil
+		}
+	}
+
+	return nil, ErrNoCandidates
+}
@@ -0,0 +1,99 @@
+package leaderelection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func podWithUUID(uuid string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"tarantool.io/instance-uuid": uuid,
+			},
+		},
+	}
+}
+
+func TestFirstReady_PicksFirstCandidate(t *testing.T) {
+	candidates := []Candidate{
+		{Address: "10.0.0.1:8081"},
+		{Address: "10.0.0.2:8081"},
+	}
+
+	elected, err := (FirstReady{}).Elect(context.Background(), candidates)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elected.Address != "10.0.0.1:8081" {
+		t.Fatalf("expected first candidate, got %s", elected.Address)
+	}
+}
+
+func TestFirstReady_NoCandidates(t *testing.T) {
+	if _, err := (FirstReady{}).Elect(context.Background(), nil); err != ErrNoCandidates {
+		t.Fatalf("expected ErrNoCandidates, got %v", err)
+	}
+}
+
+func TestLowestUUID_PicksSmallestUUID(t *testing.T) {
+	candidates := []Candidate{
+		{Address: "10.0.0.1:8081", Pod: podWithUUID("b")},
+		{Address: "10.0.0.2:8081", Pod: podWithUUID("a")},
+		{Address: "10.0.0.3:8081", Pod: podWithUUID("c")},
+	}
+
+	elected, err := (LowestUUID{}).Elect(context.Background(), candidates)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elected.Address != "10.0.0.2:8081" {
+		t.Fatalf("expected candidate with lowest uuid, got %s", elected.Address)
+	}
+}
+
+func TestLowestUUID_SkipsCandidatesWithoutPod(t *testing.T) {
+	candidates := []Candidate{
+		{Address: "10.0.0.1:8081"},
+		{Address: "10.0.0.2:8081", Pod: podWithUUID("a")},
+	}
+
+	elected, err := (LowestUUID{}).Elect(context.Background(), candidates)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elected.Address != "10.0.0.2:8081" {
+		t.Fatalf("expected only candidate with a uuid label, got %s", elected.Address)
+	}
+}
+
+func TestHealthProbe_SkipsUnresponsiveCandidate(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+
+	candidates := []Candidate{
+		{Address: "127.0.0.1:1"},
+		{Address: healthy.Listener.Addr().String()},
+	}
+
+	elected, err := (HealthProbe{}).Elect(context.Background(), candidates)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elected.Address != candidates[1].Address {
+		t.Fatalf("expected the responsive candidate, got %s", elected.Address)
+	}
+}
+
+func TestHealthProbe_NoCandidates(t *testing.T) {
+	if _, err := (HealthProbe{}).Elect(context.Background(), nil); err != ErrNoCandidates {
+		t.Fatalf("expected ErrNoCandidates, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package leaderlease
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// FormatHolderAddress formats ip and port as the host:port string a Lease's
+// HolderIdentity records. IPv6 addresses are bracketed ("[fe80::1]:8081") so the
+// result round-trips through ParseHolderAddress and through net.SplitHostPort, the
+// same as any other host:port literal.
+func FormatHolderAddress(ip string, port int) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return fmt.Sprintf("%s:%d", ip, port)
+	}
+	if addr.Is4() {
+		return fmt.Sprintf("%s:%d", addr, port)
+	}
+	return fmt.Sprintf("[%s]:%d", addr, port)
+}
+
+// ParseHolderAddress parses a host:port string as stored in a Lease's HolderIdentity —
+// either "1.2.3.4:8081" or the bracketed IPv6 form "[fe80::1]:8081" — into its address
+// and port, so callers can compare holders by parsed address instead of raw string
+// equality across otherwise-equivalent representations of the same address.
+func ParseHolderAddress(holder string) (netip.Addr, uint16, error) {
+	host, portStr, err := net.SplitHostPort(holder)
+	if err != nil {
+		return netip.Addr{}, 0, fmt.Errorf("splitting holder address %q: %w", holder, err)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, 0, fmt.Errorf("parsing holder address %q: %w", holder, err)
+	}
+
+	port, err := net.LookupPort("tcp", portStr)
+	if err != nil {
+		return netip.Addr{}, 0, fmt.Errorf("parsing holder port %q: %w", holder, err)
+	}
+
+	return addr, uint16(port), nil
+}
+
+// SameHolder reports whether a and b name the same address and port, even if they spell
+// the same IPv6 address differently (e.g. "[fe80::1]:8081" and "[fe80:0::1]:8081"), or
+// one is an IPv4-mapped IPv6 representation of the other. A string that doesn't parse
+// as host:port (such as an unbracketed IPv6 literal) is compared as a raw string.
+func SameHolder(a, b string) bool {
+	addrA, portA, errA := ParseHolderAddress(a)
+	addrB, portB, errB := ParseHolderAddress(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return portA == portB && addrA.Unmap() == addrB.Unmap()
+}
@@ -0,0 +1,76 @@
+package leaderlease
+
+import "testing"
+
+func TestFormatHolderAddress(t *testing.T) {
+	cases := []struct {
+		ip       string
+		port     int
+		expected string
+	}{
+		{"1.2.3.4", 8081, "1.2.3.4:8081"},
+		{"fe80::1", 8081, "[fe80::1]:8081"},
+		{"not-an-ip", 8081, "not-an-ip:8081"},
+	}
+
+	for _, c := range cases {
+		got := FormatHolderAddress(c.ip, c.port)
+		if got != c.expected {
+			t.Errorf("FormatHolderAddress(%q, %d) = %q, want %q", c.ip, c.port, got, c.expected)
+		}
+	}
+}
+
+func TestParseHolderAddress(t *testing.T) {
+	cases := []struct {
+		holder      string
+		expectedIP  string
+		expectedErr bool
+	}{
+		{"1.2.3.4:8081", "1.2.3.4", false},
+		{"[fe80::1]:8081", "fe80::1", false},
+		{"not-a-holder", "", true},
+		{"[fe80::1]:not-a-port", "", true},
+	}
+
+	for _, c := range cases {
+		addr, port, err := ParseHolderAddress(c.holder)
+		if c.expectedErr {
+			if err == nil {
+				t.Errorf("ParseHolderAddress(%q): expected an error, got addr=%s port=%d", c.holder, addr, port)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseHolderAddress(%q): unexpected error: %s", c.holder, err)
+			continue
+		}
+		if addr.String() != c.expectedIP {
+			t.Errorf("ParseHolderAddress(%q) addr = %s, want %s", c.holder, addr, c.expectedIP)
+		}
+		if port != 8081 {
+			t.Errorf("ParseHolderAddress(%q) port = %d, want 8081", c.holder, port)
+		}
+	}
+}
+
+func TestSameHolder(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"1.2.3.4:8081", "1.2.3.4:8081", true},
+		{"[fe80::1]:8081", "[fe80::1]:8081", true},
+		{"[fe80::1]:8081", "[fe80::2]:8081", false},
+		{"1.2.3.4:8081", "1.2.3.4:8082", false},
+		{"1.2.3.4:8081", "[fe80::1]:8081", false},
+		{"garbage-a", "garbage-a", true},
+		{"garbage-a", "garbage-b", false},
+	}
+
+	for _, c := range cases {
+		if got := SameHolder(c.a, c.b); got != c.expected {
+			t.Errorf("SameHolder(%q, %q) = %v, want %v", c.a, c.b, got, c.expected)
+		}
+	}
+}
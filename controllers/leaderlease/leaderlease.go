@@ -0,0 +1,107 @@
+// Package leaderlease tracks a Cluster's leader via a coordination.k8s.io Lease,
+// rather than re-scanning Endpoints on every reconcile. A Lease survives an operator
+// restart and is updated with a single conflict-checked write, instead of depending on
+// whatever stale copy of the Endpoints object a reconcile happened to read.
+package leaderlease
+
+import (
+	"context"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Tracker creates and renews a Cluster's leader Lease.
+type Tracker struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// Get returns the Lease named name in namespace, or nil if it doesn't exist yet.
+func (t *Tracker) Get(ctx context.Context, namespace, name string) (*coordinationv1.Lease, error) {
+	lease := &coordinationv1.Lease{}
+	err := t.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, lease)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+// IsHeld reports whether lease has a holder whose lease duration hasn't elapsed since
+// its last renewal, as of now.
+func IsHeld(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease == nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return false
+	}
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+
+	duration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	return now.Before(lease.Spec.RenewTime.Add(duration))
+}
+
+// Renew creates or updates the Lease named name in namespace so holderIdentity becomes
+// its current holder, owned by owner. AcquireTime is only reset when the holder
+// actually changes; RenewTime and the duration are refreshed unconditionally.
+func (t *Tracker) Renew(ctx context.Context, owner client.Object, namespace, name, holderIdentity string, duration time.Duration, now time.Time) (*coordinationv1.Lease, error) {
+	renewTime := metav1.NewMicroTime(now)
+	durationSeconds := int32(duration.Seconds())
+
+	lease, err := t.Get(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if lease == nil {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holderIdentity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		}
+		if err := controllerutil.SetControllerReference(owner, lease, t.Scheme); err != nil {
+			return nil, err
+		}
+		return lease, t.Client.Create(ctx, lease)
+	}
+
+	if lease.Spec.HolderIdentity == nil || !SameHolder(*lease.Spec.HolderIdentity, holderIdentity) {
+		lease.Spec.AcquireTime = &renewTime
+	}
+	lease.Spec.HolderIdentity = &holderIdentity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &renewTime
+
+	return lease, t.Client.Update(ctx, lease)
+}
+
+// Release clears the Lease named name in namespace's holder, so the next reconcile's
+// IsHeld check fails and re-elects immediately instead of waiting out the rest of the
+// current holder's LeaseDurationSeconds. Used when the current holder turns out to be
+// unreachable despite still renewing its lease on time. A no-op if the Lease doesn't
+// exist.
+func (t *Tracker) Release(ctx context.Context, namespace, name string) error {
+	lease, err := t.Get(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	if lease == nil || lease.Spec.HolderIdentity == nil {
+		return nil
+	}
+
+	lease.Spec.HolderIdentity = nil
+	return t.Client.Update(ctx, lease)
+}
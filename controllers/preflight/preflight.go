@@ -0,0 +1,212 @@
+// Package preflight runs a battery of checks that a namespace is actually ready to
+// host a Cluster/Role/ReplicasetTemplate, before one is ever admitted: CRDs
+// installed, RBAC granted, a storage class present, and in-cluster DNS resolving the
+// advertise hostname shape. No PodSecurity or SYS_ADMIN check is run. Each
+// check is independent and returns a Result rather than an error, so one missing
+// prerequisite doesn't stop the rest of the battery from running.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+)
+
+// Status is the outcome of a single Check, mirroring PreflightResultStatus.
+type Status string
+
+const (
+	Pass Status = "Pass"
+	Warn Status = "Warn"
+	Fail Status = "Fail"
+)
+
+// Result is one Check's outcome.
+type Result struct {
+	Name        string
+	Status      Status
+	Message     string
+	Remediation string
+}
+
+// Config carries everything a Check needs to evaluate TargetNamespace.
+type Config struct {
+	Client client.Client
+	// TargetNamespace is the namespace the battery is run against.
+	TargetNamespace string
+	// ClusterID and Alias are used to build the advertise hostname AdvertiseDNSResolves
+	// probes, in the form "<Alias>.<ClusterID>.<TargetNamespace>.svc.cluster.local".
+	// Both default to "preflight-probe" when unset, since AdvertiseDNSResolves only
+	// needs some name in the expected shape, not a real Cluster's.
+	ClusterID string
+	Alias     string
+	// StorageClassName is the PVC storage class to check for. Skipped when unset.
+	StorageClassName string
+}
+
+// Check evaluates one prerequisite against cfg.
+type Check func(ctx context.Context, cfg Config) Result
+
+// All is the default battery run by PreflightCheckReconciler.
+var All = []Check{
+	CRDsInstalled,
+	RBACGranted,
+	AdvertiseDNSResolves,
+	StorageClassExists,
+}
+
+// CRDsInstalled checks that Cluster, Role, and ReplicasetTemplate are registered with
+// the API server's RESTMapper, i.e. their CRDs are installed.
+func CRDsInstalled(ctx context.Context, cfg Config) Result {
+	kinds := []string{"Cluster", "Role", "ReplicasetTemplate"}
+	mapper := cfg.Client.RESTMapper()
+
+	for _, kind := range kinds {
+		gvk := tarantooliov1alpha1.GroupVersion.WithKind(kind)
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if meta.IsNoMatchError(err) {
+				return Result{
+					Name:        "CRDsInstalled",
+					Status:      Fail,
+					Message:     fmt.Sprintf("%s CRD is not installed", gvk.GroupKind()),
+					Remediation: "apply config/crd/bases before creating any Cluster/Role/ReplicasetTemplate",
+				}
+			}
+			return Result{Name: "CRDsInstalled", Status: Fail, Message: err.Error()}
+		}
+	}
+
+	return Result{Name: "CRDsInstalled", Status: Pass, Message: "Cluster, Role, and ReplicasetTemplate CRDs are installed"}
+}
+
+// RBACGranted checks that the operator's own ServiceAccount can list and patch Pods
+// and StatefulSets, and list Endpoints, in cfg.TargetNamespace -- the verbs
+// cluster_controller.go and role_controller.go need on every reconcile.
+func RBACGranted(ctx context.Context, cfg Config) Result {
+	checks := []struct {
+		resource string
+		verb     string
+	}{
+		{"pods", "list"},
+		{"pods", "patch"},
+		{"statefulsets", "list"},
+		{"statefulsets", "patch"},
+		{"endpoints", "list"},
+	}
+
+	var denied []string
+	for _, check := range checks {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: cfg.TargetNamespace,
+					Verb:      check.verb,
+					Resource:  check.resource,
+				},
+			},
+		}
+		if err := cfg.Client.Create(ctx, review); err != nil {
+			return Result{Name: "RBACGranted", Status: Fail, Message: fmt.Sprintf("SelfSubjectAccessReview: %s", err)}
+		}
+		if !review.Status.Allowed {
+			denied = append(denied, fmt.Sprintf("%s %s", check.verb, check.resource))
+		}
+	}
+
+	if len(denied) > 0 {
+		return Result{
+			Name:        "RBACGranted",
+			Status:      Fail,
+			Message:     fmt.Sprintf("missing RBAC for: %v", denied),
+			Remediation: fmt.Sprintf("grant the operator role %v in namespace %q", denied, cfg.TargetNamespace),
+		}
+	}
+
+	return Result{Name: "RBACGranted", Status: Pass, Message: "operator RBAC covers Pods/StatefulSets/Endpoints"}
+}
+
+// AdvertiseDNSResolves checks that "<alias>.<clusterId>.<ns>.svc.cluster.local"
+// resolves, the hostname shape cluster_controller.go advertises pods under. A probe
+// name is used rather than a real Cluster's, since this runs before any Cluster
+// exists. No Service or Pod answers to the default probe name, so a Warn is expected
+// until one does and doesn't by itself show that in-cluster DNS is broken.
+func AdvertiseDNSResolves(ctx context.Context, cfg Config) Result {
+	clusterID := cfg.ClusterID
+	if clusterID == "" {
+		clusterID = "preflight-probe"
+	}
+	alias := cfg.Alias
+	if alias == "" {
+		alias = "preflight-probe"
+	}
+
+	host := fmt.Sprintf("%s.%s.%s.svc.cluster.local", alias, clusterID, cfg.TargetNamespace)
+	if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+		return Result{
+			Name:        "AdvertiseDNSResolves",
+			Status:      Warn,
+			Message:     fmt.Sprintf("%s did not resolve: %s", host, err),
+			Remediation: "this is expected until a headless Service/Pod with that name exists; re-run once the first StatefulSet is created",
+		}
+	}
+
+	return Result{Name: "AdvertiseDNSResolves", Status: Pass, Message: fmt.Sprintf("%s resolves", host)}
+}
+
+// StorageClassExists checks that cfg.StorageClassName exists, so a Role's
+// ReplicasetTemplate PVCs don't fail to bind after a Cluster is already admitted.
+// Skipped (Pass) when cfg.StorageClassName is unset, since not every Cluster uses
+// PersistentVolumeClaims.
+func StorageClassExists(ctx context.Context, cfg Config) Result {
+	if cfg.StorageClassName == "" {
+		return Result{Name: "StorageClassExists", Status: Pass, Message: "no storage class configured, skipping"}
+	}
+
+	sc := &storagev1.StorageClass{}
+	err := cfg.Client.Get(ctx, client.ObjectKey{Name: cfg.StorageClassName}, sc)
+	if apierrors.IsNotFound(err) {
+		return Result{
+			Name:        "StorageClassExists",
+			Status:      Fail,
+			Message:     fmt.Sprintf("storage class %q not found", cfg.StorageClassName),
+			Remediation: "create the storage class or point ReplicasetTemplate's PVC templates at one that exists",
+		}
+	}
+	if err != nil {
+		return Result{Name: "StorageClassExists", Status: Fail, Message: err.Error()}
+	}
+
+	return Result{Name: "StorageClassExists", Status: Pass, Message: fmt.Sprintf("storage class %q exists", cfg.StorageClassName)}
+}
+
+// RunAll runs every Check in All against cfg and returns their Results in order.
+func RunAll(ctx context.Context, cfg Config) []Result {
+	results := make([]Result, 0, len(All))
+	for _, check := range All {
+		results = append(results, check(ctx, cfg))
+	}
+	return results
+}
+
+// ToCRDResults converts preflight Results to the PreflightCheckResult shape stored on
+// PreflightCheck.Status.
+func ToCRDResults(results []Result) []tarantooliov1alpha1.PreflightCheckResult {
+	out := make([]tarantooliov1alpha1.PreflightCheckResult, 0, len(results))
+	for _, r := range results {
+		out = append(out, tarantooliov1alpha1.PreflightCheckResult{
+			Name:        r.Name,
+			Status:      tarantooliov1alpha1.PreflightResultStatus(r.Status),
+			Message:     r.Message,
+			Remediation: r.Remediation,
+		})
+	}
+	return out
+}
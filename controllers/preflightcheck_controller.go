@@ -0,0 +1,99 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	"github.com/tarantool/tarantool-operator/controllers/preflight"
+)
+
+// PreflightCheckReconciler runs the preflight check battery against
+// Spec.TargetNamespace and records the results on Status, so an operator can confirm a
+// namespace is ready for a Cluster the same way `test_checkk8sversion`-style
+// pre-installation frameworks do for a bare cluster, without needing a separate CLI.
+type PreflightCheckReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=tarantool.io,resources=preflightchecks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=tarantool.io,resources=preflightchecks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews,verbs=create
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get
+
+// Reconcile runs the preflight.All battery against the PreflightCheck's
+// Spec.TargetNamespace (defaulting to its own namespace) and writes the results to
+// Status.Results. It always re-runs on every reconcile rather than caching, since a
+// namespace's readiness (RBAC, storage classes, DNS) can change without the
+// PreflightCheck object itself changing.
+func (r *PreflightCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+	reqLogger.Info("Reconciling PreflightCheck")
+
+	check := &tarantooliov1alpha1.PreflightCheck{}
+	if err := r.Get(ctx, req.NamespacedName, check); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	targetNamespace := check.Spec.TargetNamespace
+	if targetNamespace == "" {
+		targetNamespace = check.GetNamespace()
+	}
+
+	results := preflight.RunAll(ctx, preflight.Config{
+		Client:          r.Client,
+		TargetNamespace: targetNamespace,
+	})
+
+	check.Status.ObservedGeneration = check.GetGeneration()
+	check.Status.Results = preflight.ToCRDResults(results)
+	if err := r.Status().Update(ctx, check); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PreflightCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&tarantooliov1alpha1.PreflightCheck{}).
+		Complete(r)
+}
@@ -30,30 +30,55 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/google/uuid"
 	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	"github.com/tarantool/tarantool-operator/controllers/kubeutil"
+	"github.com/tarantool/tarantool-operator/controllers/topology"
+	"github.com/tarantool/tarantool-operator/controllers/utils"
 )
 
+// templateHashAnnotation carries a hash of a StatefulSet's pod template, set at
+// creation time by CreateStatefulSetFromTemplate for upgrade drift detection and kept
+// up to date by Reconcile's rollout loop, which also uses it to tell whether a
+// StatefulSet already reflects its ReplicasetTemplate's current revision.
+const templateHashAnnotation = "tarantool.io/templateHash"
+
 // RoleReconciler reconciles a Role object
 type RoleReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits Events for things a Role's conditions alone don't surface well,
+	// e.g. the deprecated rolesToAssign annotation/label fallback, StatefulSet
+	// creation/drift/rollout, and an ambiguous template selector. Set by
+	// SetupWithManager if nil.
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=tarantool.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
@@ -74,7 +99,7 @@ func (r *RoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	reqLogger.Info("Reconciling Role")
 
 	role := &tarantooliov1alpha1.Role{}
-	err := r.Get(context.TODO(), req.NamespacedName, role)
+	err := r.Get(ctx, req.NamespacedName, role)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return ctrl.Result{}, nil
@@ -86,6 +111,8 @@ func (r *RoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, fmt.Errorf("Orphan role %s", role.GetName())
 	}
 
+	originalStatus := role.Status
+
 	templateSelector, err := metav1.LabelSelectorAsSelector(role.Spec.Selector)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -102,126 +129,373 @@ func (r *RoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	}
 
 	stsList := &appsv1.StatefulSetList{}
-	if err := r.List(context.TODO(), stsList, &client.ListOptions{LabelSelector: s}); err != nil {
+	if err := r.List(ctx, stsList, &client.ListOptions{LabelSelector: s}); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// ensure num of statefulsets matches user expectations
+	// ensure num of statefulsets matches user expectations. A replicaset marked for
+	// removal isn't deleted directly here -- it's handed off to ClusterReconciler by
+	// zeroing its tarantool.io/replicaset-weight annotation, the same signal
+	// reconcileDraining already watches for on downscale, which migrates its vshard
+	// buckets away, expels its instances, and only then deletes the StatefulSet.
 	if len(stsList.Items) > int(*role.Spec.NumReplicasets) {
 		reqLogger.Info("Role", "more instances", *role.Spec.NumReplicasets)
 		for i := len(stsList.Items); i > int(*role.Spec.NumReplicasets); i-- {
 			sts := &appsv1.StatefulSet{}
-			sts.Name = fmt.Sprintf("%s-%d", role.Name, i-1)
-			sts.Namespace = req.Namespace
-			reqLogger.Info("ROLE DOWNSCALE", "will remove", sts.Name)
+			name := types.NamespacedName{Namespace: req.Namespace, Name: fmt.Sprintf("%s-%d", role.Name, i-1)}
 
-			if err := r.Get(context.TODO(), types.NamespacedName{Namespace: sts.Namespace, Name: sts.Name}, sts); err != nil {
+			if err := r.Get(ctx, name, sts); err != nil {
 				if errors.IsNotFound(err) {
 					continue
 				}
 				return ctrl.Result{}, err
 			}
 
-			stsAnnotations := sts.GetAnnotations()
-			if stsAnnotations["tarantool.io/scheduledDelete"] == "1" {
-				reqLogger.Info("statefulset is ready for deletion")
+			if sts.GetAnnotations()["tarantool.io/replicaset-weight"] == "0" {
+				reqLogger.Info("replicaset already draining for removal", "StatefulSet.Name", sts.GetName())
+				continue
 			}
 
-			// if err := r.client.Delete(context.TODO(), sts); err != nil {
-			// 	return reconcile.Result{}, err
-			// }
+			reqLogger.Info("scaling down role: draining replicaset before removal", "StatefulSet.Name", sts.GetName())
+			if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+				updated := o.(*appsv1.StatefulSet)
+				if updated.Annotations == nil {
+					updated.Annotations = make(map[string]string)
+				}
+				updated.Annotations["tarantool.io/replicaset-weight"] = "0"
+				return nil
+			}); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(role, corev1.EventTypeNormal, "StatefulSetScheduledForDeletion",
+				"%s marked for removal, draining before deletion", sts.GetName())
 		}
 	}
 
 	templateList := &tarantooliov1alpha1.ReplicasetTemplateList{}
-	if err := r.List(context.TODO(), templateList, &client.ListOptions{LabelSelector: templateSelector}); err != nil {
+	if err := r.List(ctx, templateList, &client.ListOptions{LabelSelector: templateSelector}); err != nil {
 		return ctrl.Result{}, err
 	}
 
 	if len(templateList.Items) == 0 {
+		r.Recorder.Event(role, corev1.EventTypeWarning, "TemplateNotFound", "no ReplicasetTemplate matches spec.selector")
+		role.Status.Conditions = tarantooliov1alpha1.SetCondition(role.Status.Conditions, tarantooliov1alpha1.ClusterCondition{
+			Type: tarantooliov1alpha1.TemplateResolved, Status: tarantooliov1alpha1.ConditionFalse,
+			Reason: "TemplateNotFound", Message: "no ReplicasetTemplate matches spec.selector",
+		})
+		role.Status.Conditions = tarantooliov1alpha1.SetCondition(role.Status.Conditions, tarantooliov1alpha1.ClusterCondition{
+			Type: tarantooliov1alpha1.Degraded, Status: tarantooliov1alpha1.ConditionTrue,
+			Reason: "TemplateNotFound", Message: "no ReplicasetTemplate matches spec.selector",
+		})
+		if !reflect.DeepEqual(originalStatus, role.Status) {
+			if err := r.Status().Update(ctx, role); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 		return ctrl.Result{}, fmt.Errorf("no template")
 	}
 
+	if len(templateList.Items) > 1 {
+		r.Recorder.Eventf(role, corev1.EventTypeWarning, "AmbiguousTemplateSelector",
+			"%d ReplicasetTemplates match spec.selector, using %s", len(templateList.Items), templateList.Items[0].GetName())
+	}
+
 	template := templateList.Items[0]
 
+	role.Status.Conditions = tarantooliov1alpha1.SetCondition(role.Status.Conditions, tarantooliov1alpha1.ClusterCondition{
+		Type: tarantooliov1alpha1.TemplateResolved, Status: tarantooliov1alpha1.ConditionTrue,
+		Reason: "TemplateResolved", Message: fmt.Sprintf("resolved to ReplicasetTemplate %s", template.GetName()),
+	})
+	role.Status.Conditions = tarantooliov1alpha1.SetCondition(role.Status.Conditions, tarantooliov1alpha1.ClusterCondition{
+		Type: tarantooliov1alpha1.Degraded, Status: tarantooliov1alpha1.ConditionFalse,
+	})
+
 	if len(stsList.Items) < int(*role.Spec.NumReplicasets) {
 		for i := 0; i < int(*role.Spec.NumReplicasets); i++ {
 			sts := &appsv1.StatefulSet{}
 			sts.Name = fmt.Sprintf("%s-%d", role.Name, i)
 			sts.Namespace = req.Namespace
 
-			if err := r.Get(context.TODO(), types.NamespacedName{Namespace: sts.Namespace, Name: sts.Name}, sts); err != nil {
+			if err := r.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: sts.Name}, sts); err != nil {
 				sts = CreateStatefulSetFromTemplate(ctx, i, fmt.Sprintf("%s-%d", role.Name, i), role, &template)
 				if err := controllerutil.SetControllerReference(role, sts, r.Scheme); err != nil {
 					return ctrl.Result{}, err
 				}
-				if err := r.Create(context.TODO(), sts); err != nil {
+				if err := kubeutil.CreateOrGetWithRetry(ctx, r.Client, sts); err != nil {
 					return ctrl.Result{}, err
 				}
+				r.Recorder.Eventf(role, corev1.EventTypeNormal, "StatefulSetCreated", "created %s", sts.GetName())
+			}
+		}
+	}
+
+	rolesToAssign, deprecated, rolesToAssignOK := rolesToAssignValue(role, &template)
+	if rolesToAssignOK && deprecated {
+		r.Recorder.Eventf(role, corev1.EventTypeWarning, "DeprecatedRolesToAssign",
+			"ReplicasetTemplate %s carries tarantool.io/rolesToAssign as an annotation/label; set spec.rolesToAssign on the Role instead", template.GetName())
+	}
+
+	strategy := role.Spec.RolloutStrategy
+	partition := int32(0)
+	maxUnavailable := 1
+	if strategy != nil {
+		if strategy.Partition != nil {
+			partition = *strategy.Partition
+		}
+		if strategy.MaxUnavailable != nil {
+			if v, err := intstr.GetScaledValueFromIntOrPercent(strategy.MaxUnavailable, len(stsList.Items), true); err == nil {
+				maxUnavailable = v
 			}
 		}
 	}
 
-	for _, sts := range stsList.Items {
+	replicasets := make([]appsv1.StatefulSet, len(stsList.Items))
+	copy(replicasets, stsList.Items)
+	sort.Slice(replicasets, func(i, j int) bool {
+		oi, _ := replicasetOrdinal(replicasets[i].GetName())
+		oj, _ := replicasetOrdinal(replicasets[j].GetName())
+		return oi > oj
+	})
+
+	var replicasCount, updatedReplicas, availableReplicas int32
+	unavailable := 0
+	for i := range replicasets {
+		sts := &replicasets[i]
+		replicasCount++
+
+		if podReady(ctx, r.Client, sts) {
+			availableReplicas++
+		} else {
+			unavailable++
+		}
+
+		revision, err := utils.HashPodTemplate(desiredPodTemplate(sts, &template, rolesToAssign, rolesToAssignOK))
+		if err != nil {
+			reqLogger.Error(err, "failed to hash desired pod template, rollout tracking will be skipped for this StatefulSet", "StatefulSet.Name", sts.GetName())
+			updatedReplicas++
+			continue
+		}
+
+		if sts.ObjectMeta.Annotations[templateHashAnnotation] == revision {
+			updatedReplicas++
+			continue
+		}
+
+		r.Recorder.Eventf(role, corev1.EventTypeNormal, "DriftDetected", "%s's pod template no longer matches ReplicasetTemplate %s", sts.GetName(), template.GetName())
+
 		if template.Spec.Replicas != sts.Spec.Replicas {
 			reqLogger.Info("Updating replicas count")
-			sts.Spec.Replicas = template.Spec.Replicas
-			if err := r.Update(context.TODO(), &sts); err != nil {
+			if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+				o.(*appsv1.StatefulSet).Spec.Replicas = template.Spec.Replicas
+				return nil
+			}); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
 
-		if template.Spec.Template.Spec.Containers[0].Image != sts.Spec.Template.Spec.Containers[0].Image {
-			reqLogger.Info("Updating container image")
-			sts.Spec.Template.Spec.Containers[0].Image = template.Spec.Template.Spec.Containers[0].Image
-			if err := r.Update(context.TODO(), &sts); err != nil {
-				return ctrl.Result{}, err
+		if strategy != nil && strategy.Type == tarantooliov1alpha1.OnDeleteRolloutStrategy {
+			continue
+		}
+
+		if strategy != nil && strategy.Type == tarantooliov1alpha1.PartitionedRolloutStrategy {
+			if ordinal, ok := replicasetOrdinal(sts.GetName()); ok && ordinal < partition {
+				reqLogger.Info("replicaset below rollout partition, leaving on its current revision", "StatefulSet.Name", sts.GetName(), "partition", partition)
+				continue
 			}
 		}
 
-		sts.Spec.Template.Spec.Containers[0].Env = template.Spec.Template.Spec.Containers[0].Env
-		reqLogger.Info("Env variables", "vars", sts.Spec.Template.Spec.Containers[0].Env)
-		if err := r.Update(context.TODO(), &sts); err != nil {
+		if unavailable > maxUnavailable {
+			reqLogger.Info("rollout MaxUnavailable reached, deferring update", "StatefulSet.Name", sts.GetName())
+			continue
+		}
+
+		reqLogger.Info("rolling out template revision", "StatefulSet.Name", sts.GetName(), "revision", revision)
+		if err := kubeutil.UpdateWithRetry(ctx, r.Client, sts, func(o client.Object) error {
+			updated := o.(*appsv1.StatefulSet)
+			templateIdx, templateOK := template.MainContainerIndex()
+			if destIdx, ok := tarantooliov1alpha1.MainContainerIndexIn(template.GetAnnotations()[tarantooliov1alpha1.MainContainerAnnotation], updated.Spec.Template.Spec.Containers); ok && templateOK {
+				updated.Spec.Template.Spec.Containers[destIdx].Image = template.Spec.Template.Spec.Containers[templateIdx].Image
+				updated.Spec.Template.Spec.Containers[destIdx].Env = template.Spec.Template.Spec.Containers[templateIdx].Env
+			}
+			if rolesToAssignOK {
+				updated.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] = rolesToAssign
+				updated.Spec.Template.Annotations["tarantool.io/rolesToAssign"] = rolesToAssign
+			}
+			updated.ObjectMeta.Annotations[templateHashAnnotation] = revision
+			if updated.Spec.Template.ObjectMeta.Annotations == nil {
+				updated.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+			}
+			updated.Spec.Template.ObjectMeta.Annotations[templateHashAnnotation] = revision
+			return nil
+		}); err != nil {
 			return ctrl.Result{}, err
 		}
+		updatedReplicas++
+		r.Recorder.Eventf(role, corev1.EventTypeNormal, "RolloutProgressed", "%s updated to template revision %s", sts.GetName(), revision)
+	}
 
-		if templateRolesToAssign, ok := template.ObjectMeta.Annotations["tarantool.io/rolesToAssign"]; ok {
-			// check rolesToAssign from annotations
-			if templateRolesToAssign != sts.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] {
-				reqLogger.Info("Updating replicaset rolesToAssign",
-					"from", sts.ObjectMeta.Annotations["tarantool.io/rolesToAssign"],
-					"to", templateRolesToAssign)
+	role.Status.Replicas = replicasCount
+	role.Status.UpdatedReplicas = updatedReplicas
+	role.Status.AvailableReplicas = availableReplicas
+	role.Status.DrainingReplicasets = drainingReplicasets(replicasets)
 
-				sts.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] = templateRolesToAssign
-				sts.Spec.Template.Annotations["tarantool.io/rolesToAssign"] = templateRolesToAssign
+	progressing := tarantooliov1alpha1.ConditionFalse
+	if updatedReplicas < replicasCount {
+		progressing = tarantooliov1alpha1.ConditionTrue
+	} else if wasProgressing, ok := findCondition(originalStatus.Conditions, tarantooliov1alpha1.Progressing); ok && wasProgressing.Status == tarantooliov1alpha1.ConditionTrue {
+		r.Recorder.Eventf(role, corev1.EventTypeNormal, "RolloutCompleted", "all %d replicasets updated to the current template revision", replicasCount)
+	}
+	role.Status.Conditions = tarantooliov1alpha1.SetCondition(role.Status.Conditions, tarantooliov1alpha1.ClusterCondition{
+		Type: tarantooliov1alpha1.Progressing, Status: progressing,
+	})
 
-				if err := r.Update(context.TODO(), &sts); err != nil {
-					return ctrl.Result{}, err
-				}
+	ready := tarantooliov1alpha1.ConditionFalse
+	if replicasCount > 0 && availableReplicas == replicasCount {
+		ready = tarantooliov1alpha1.ConditionTrue
+	}
+	role.Status.Conditions = tarantooliov1alpha1.SetCondition(role.Status.Conditions, tarantooliov1alpha1.ClusterCondition{
+		Type: tarantooliov1alpha1.Ready, Status: ready,
+	})
+
+	if !reflect.DeepEqual(originalStatus, role.Status) {
+		if err := r.Status().Update(ctx, role); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// findCondition returns the condition of type t in conditions, if any.
+func findCondition(conditions []tarantooliov1alpha1.ClusterCondition, t tarantooliov1alpha1.ClusterConditionType) (tarantooliov1alpha1.ClusterCondition, bool) {
+	for _, c := range conditions {
+		if c.Type == t {
+			return c, true
+		}
+	}
+	return tarantooliov1alpha1.ClusterCondition{}, false
+}
+
+// drainingReplicasets reports every StatefulSet in replicasets a NumReplicasets
+// downscale has marked for removal (tarantool.io/replicaset-weight "0") that hasn't
+// finished being expelled and deleted yet, recomputed from the StatefulSets' own
+// annotations on every reconcile rather than tracked incrementally, so the status is
+// accurate after an operator restart too.
+func drainingReplicasets(replicasets []appsv1.StatefulSet) []tarantooliov1alpha1.ReplicasetDrainStatus {
+	var draining []tarantooliov1alpha1.ReplicasetDrainStatus
+	for _, sts := range replicasets {
+		if sts.GetAnnotations()["tarantool.io/replicaset-weight"] != "0" {
+			continue
+		}
+
+		phase := tarantooliov1alpha1.ReplicasetDraining
+		if sts.GetAnnotations()[annotationScheduledDelete] == "1" {
+			phase = tarantooliov1alpha1.ReplicasetExpelling
+		}
+		draining = append(draining, tarantooliov1alpha1.ReplicasetDrainStatus{Name: sts.GetName(), Phase: phase})
+	}
+	return draining
+}
+
+// replicasetTemplateSelectorIndex is the field index SetupWithManager registers on
+// Role, keyed by roleSelectorIndexKeys, so rolesForTemplate can look up candidate
+// Roles for a changed ReplicasetTemplate by label instead of listing every Role in
+// the cluster.
+const replicasetTemplateSelectorIndex = "spec.selector.matchLabels"
+
+// anyTemplateIndexKey is the replicasetTemplateSelectorIndex value a Role with no
+// indexable Spec.Selector.MatchLabels is filed under, so it's still found on every
+// ReplicasetTemplate event -- rolesForTemplate re-checks the real Selector before
+// enqueueing it, so this only costs an extra (cheap, indexed) List, not correctness.
+const anyTemplateIndexKey = "*"
+
+// roleSelectorIndexKeys returns the replicasetTemplateSelectorIndex values obj (a
+// *Role) should be filed under: one "key=value" string per entry in its
+// Spec.Selector.MatchLabels. A Role selecting purely on MatchExpressions, or with no
+// selector at all, has no label pair to index by, so it's filed under
+// anyTemplateIndexKey instead.
+func roleSelectorIndexKeys(obj client.Object) []string {
+	role := obj.(*tarantooliov1alpha1.Role)
+	if role.Spec.Selector == nil || len(role.Spec.Selector.MatchLabels) == 0 {
+		return []string{anyTemplateIndexKey}
+	}
+
+	keys := make([]string, 0, len(role.Spec.Selector.MatchLabels))
+	for k, v := range role.Spec.Selector.MatchLabels {
+		keys = append(keys, k+"="+v)
+	}
+	return keys
+}
+
+// replicasetTemplateChangedPredicate ignores ReplicasetTemplate updates that don't
+// touch anything the rollout loop reads from it: Spec (image, env, replicas, ...) and
+// the deprecated tarantool.io/rolesToAssign annotation/label fallback.
+func replicasetTemplateChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old, ok := e.ObjectOld.(*tarantooliov1alpha1.ReplicasetTemplate)
+			next, ok2 := e.ObjectNew.(*tarantooliov1alpha1.ReplicasetTemplate)
+			if !ok || !ok2 {
+				return true
 			}
-		} else {
-			// check rolesToAssign from labels (deprecated)
-			templateRolesToAssignFromLabels, ok := template.ObjectMeta.Labels["tarantool.io/rolesToAssign"]
-			if ok && templateRolesToAssignFromLabels != sts.ObjectMeta.Labels["tarantool.io/rolesToAssign"] {
-				reqLogger.Info("Updating replicaset rolesToAssign from labels",
-					"from", sts.ObjectMeta.Labels["tarantool.io/rolesToAssign"],
-					"to", templateRolesToAssignFromLabels)
+			return !reflect.DeepEqual(old.Spec, next.Spec) ||
+				old.GetAnnotations()["tarantool.io/rolesToAssign"] != next.GetAnnotations()["tarantool.io/rolesToAssign"] ||
+				old.GetLabels()["tarantool.io/rolesToAssign"] != next.GetLabels()["tarantool.io/rolesToAssign"]
+		},
+	}
+}
+
+// rolesForTemplate finds every Role that selects ReplicasetTemplate a, using
+// replicasetTemplateSelectorIndex to list only candidate Roles in a's namespace
+// instead of scanning every Role in the cluster. The index only narrows by individual
+// label pairs (or anyTemplateIndexKey), so each candidate's real Spec.Selector is
+// re-checked against a's labels before it's enqueued.
+func (r *RoleReconciler) rolesForTemplate(a client.Object) []reconcile.Request {
+	candidateKeys := []string{anyTemplateIndexKey}
+	for k, v := range a.GetLabels() {
+		candidateKeys = append(candidateKeys, k+"="+v)
+	}
 
-				sts.ObjectMeta.Labels["tarantool.io/rolesToAssign"] = templateRolesToAssignFromLabels
-				sts.Spec.Template.Labels["tarantool.io/rolesToAssign"] = templateRolesToAssignFromLabels
+	seen := map[types.NamespacedName]struct{}{}
+	var requests []reconcile.Request
+	for _, key := range candidateKeys {
+		roleList := &tarantooliov1alpha1.RoleList{}
+		if err := r.Client.List(context.Background(), roleList,
+			client.InNamespace(a.GetNamespace()),
+			client.MatchingFields{replicasetTemplateSelectorIndex: key},
+		); err != nil {
+			log.Log.Error(err, "failed to list Roles for ReplicasetTemplate change, skipping this event", "ReplicasetTemplate.Name", a.GetName(), "ReplicasetTemplate.Namespace", a.GetNamespace())
+			return nil
+		}
 
-				if err := r.Update(context.TODO(), &sts); err != nil {
-					return ctrl.Result{}, err
-				}
+		for _, role := range roleList.Items {
+			selector, err := metav1.LabelSelectorAsSelector(role.Spec.Selector)
+			if err != nil || !selector.Matches(labels.Set(a.GetLabels())) {
+				continue
 			}
+
+			name := types.NamespacedName{Namespace: role.GetNamespace(), Name: role.GetName()}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			requests = append(requests, reconcile.Request{NamespacedName: name})
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("role-controller")
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &tarantooliov1alpha1.Role{}, replicasetTemplateSelectorIndex, roleSelectorIndexKeys); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tarantooliov1alpha1.Role{}).
 		Watches(&source.Kind{Type: &tarantooliov1alpha1.Role{}}, &handler.EnqueueRequestForObject{}).
@@ -229,27 +503,19 @@ func (r *RoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			IsController: true,
 			OwnerType:    &tarantooliov1alpha1.Role{},
 		}).
-		Watches(&source.Kind{Type: &tarantooliov1alpha1.ReplicasetTemplate{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
-			roleList := &tarantooliov1alpha1.RoleList{}
-			if err := r.Client.List(context.TODO(), roleList, &client.ListOptions{}); err != nil {
-				mgr.GetLogger().Info("FUCK")
-			}
-
-			res := []reconcile.Request{}
-			for _, role := range roleList.Items {
-				res = append(res, reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Name:      role.GetName(),
-						Namespace: role.GetNamespace(),
-					},
-				})
-			}
-			return res
-		})).
+		Watches(&source.Kind{Type: &tarantooliov1alpha1.ReplicasetTemplate{}},
+			handler.EnqueueRequestsFromMapFunc(r.rolesForTemplate),
+			builder.WithPredicates(replicasetTemplateChangedPredicate()),
+		).
 		Complete(r)
 }
 
-// CreateStatefulSetFromTemplate .
+// CreateStatefulSetFromTemplate builds the StatefulSet for one of role's replicasets by
+// copying rs's full StatefulSetSpec wholesale (so sidecars, extra volumes, and
+// pod-level scheduling fields in rs all carry over) and layering role's overrides on
+// top. Only fields that must target the Tarantool container specifically -- the
+// SecurityContext override here, and PodOverlay's env/volume merging -- use rs's
+// MainContainerIndex rather than assuming Containers[0].
 func CreateStatefulSetFromTemplate(ctx context.Context, replicasetNumber int, name string, role *tarantooliov1alpha1.Role, rs *tarantooliov1alpha1.ReplicasetTemplate) *appsv1.StatefulSet {
 	reqLogger := log.FromContext(ctx)
 
@@ -271,10 +537,15 @@ func CreateStatefulSetFromTemplate(ctx context.Context, replicasetNumber int, na
 
 	privileged := false
 
-	sts.Spec.Template.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{
-		Privileged: &privileged,
+	if idx, ok := rs.MainContainerIndex(); ok {
+		sts.Spec.Template.Spec.Containers[idx].SecurityContext = &corev1.SecurityContext{
+			Privileged: &privileged,
+		}
 	}
 
+	applyPodOverlay(sts, rs, role.Spec.PodOverlay)
+	applyRoleScheduling(sts, role.Spec.RoleScheduling)
+
 	sts.Spec.ServiceName = role.GetAnnotations()["tarantool.io/cluster-id"]
 	replicasetUUID := uuid.NewSHA1(space, []byte(sts.GetName()))
 	sts.ObjectMeta.Labels["tarantool.io/replicaset-uuid"] = replicasetUUID.String()
@@ -290,5 +561,183 @@ func CreateStatefulSetFromTemplate(ctx context.Context, replicasetNumber int, na
 	sts.Spec.Template.Labels["tarantool.io/replicaset-uuid"] = replicasetUUID.String()
 	sts.Spec.Template.Labels["tarantool.io/vshardGroupName"] = role.GetLabels()["tarantool.io/role"]
 
+	applyRolesToAssign(sts, role, rs)
+	applyVshardConfig(sts, role)
+
+	if templateHash, err := utils.HashPodTemplate(sts.Spec.Template); err == nil {
+		sts.ObjectMeta.Annotations[templateHashAnnotation] = templateHash
+
+		if sts.Spec.Template.ObjectMeta.Annotations == nil {
+			sts.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+		}
+		sts.Spec.Template.ObjectMeta.Annotations[templateHashAnnotation] = templateHash
+	} else {
+		reqLogger.Error(err, "failed to hash pod template, upgrade drift detection will be skipped for this StatefulSet")
+	}
+
 	return sts
 }
+
+// desiredPodTemplate returns the pod template sts should carry once Reconcile syncs
+// template's image, env, and (if ok) rolesToAssign onto it -- the same merge the
+// rollout loop applies with UpdateWithRetry -- with its own templateHashAnnotation
+// stripped so hashing it is not self-referential.
+func desiredPodTemplate(sts *appsv1.StatefulSet, template *tarantooliov1alpha1.ReplicasetTemplate, rolesToAssign string, rolesToAssignOK bool) corev1.PodTemplateSpec {
+	desired := *sts.Spec.Template.DeepCopy()
+	templateIdx, templateOK := template.MainContainerIndex()
+	if destIdx, ok := tarantooliov1alpha1.MainContainerIndexIn(template.GetAnnotations()[tarantooliov1alpha1.MainContainerAnnotation], desired.Spec.Containers); ok && templateOK {
+		desired.Spec.Containers[destIdx].Image = template.Spec.Template.Spec.Containers[templateIdx].Image
+		desired.Spec.Containers[destIdx].Env = template.Spec.Template.Spec.Containers[templateIdx].Env
+	}
+
+	if rolesToAssignOK {
+		if desired.ObjectMeta.Annotations == nil {
+			desired.ObjectMeta.Annotations = make(map[string]string)
+		}
+		desired.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] = rolesToAssign
+	}
+	delete(desired.ObjectMeta.Annotations, templateHashAnnotation)
+
+	return desired
+}
+
+// replicasetOrdinal parses the trailing "-<N>" ordinal off a replicaset StatefulSet's
+// name (e.g. "storage-2" -> 2, true), the same naming CreateStatefulSetFromTemplate
+// uses. ok is false if name doesn't end in "-<N>".
+func replicasetOrdinal(name string) (int32, bool) {
+	idx := strings.LastIndex(name, "-")
+	if idx < 0 {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(name[idx+1:], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(n), true
+}
+
+// podReady reports whether sts's replicasetOrdinal-0 pod exists and has PodReady True,
+// the signal the rollout loop uses to bound MaxUnavailable. A StatefulSet with no
+// replicas, or whose pod isn't found yet, counts as not ready.
+func podReady(ctx context.Context, c client.Client, sts *appsv1.StatefulSet) bool {
+	if sts.Spec.Replicas == nil || *sts.Spec.Replicas == 0 {
+		return false
+	}
+
+	pod := &corev1.Pod{}
+	name := types.NamespacedName{Namespace: sts.GetNamespace(), Name: fmt.Sprintf("%s-0", sts.GetName())}
+	if err := c.Get(ctx, name, pod); err != nil {
+		return false
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// rolesToAssignValue resolves the tarantool.io/rolesToAssign annotation value a
+// replicaset's StatefulSet should carry: role.Spec.RolesToAssign takes precedence,
+// JSON-encoded the same way the annotation always has been; failing that, rs's own
+// annotation is used, then its (deprecated) label. ok is false when none of the three
+// are set. deprecated is true only when the annotation/label fallback was used, so
+// callers can surface a one-time warning about the typed field replacing it.
+func rolesToAssignValue(role *tarantooliov1alpha1.Role, rs *tarantooliov1alpha1.ReplicasetTemplate) (value string, deprecated bool, ok bool) {
+	if len(role.Spec.RolesToAssign) > 0 {
+		encoded, err := json.Marshal(role.Spec.RolesToAssign)
+		if err != nil {
+			return "", false, false
+		}
+		return string(encoded), false, true
+	}
+
+	if annotation, ok := rs.ObjectMeta.Annotations["tarantool.io/rolesToAssign"]; ok {
+		return annotation, true, true
+	}
+
+	if label, ok := rs.ObjectMeta.Labels["tarantool.io/rolesToAssign"]; ok {
+		return label, true, true
+	}
+
+	return "", false, false
+}
+
+// applyRolesToAssign sets sts's tarantool.io/rolesToAssign annotation from
+// role.Spec.RolesToAssign, falling back to whatever rs's pod template already carries
+// (the deprecated annotation/label) when RolesToAssign is unset, so a Role created
+// before the typed field existed keeps working unchanged.
+func applyRolesToAssign(sts *appsv1.StatefulSet, role *tarantooliov1alpha1.Role, rs *tarantooliov1alpha1.ReplicasetTemplate) {
+	rolesToAssign, _, ok := rolesToAssignValue(role, rs)
+	if !ok {
+		return
+	}
+
+	sts.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] = rolesToAssign
+	sts.Spec.Template.Annotations["tarantool.io/rolesToAssign"] = rolesToAssign
+}
+
+// applyVshardConfig overrides the vshard weight/group annotations CreateStatefulSetFromTemplate
+// defaults, when role.Spec.Weight/VshardGroup are set.
+func applyVshardConfig(sts *appsv1.StatefulSet, role *tarantooliov1alpha1.Role) {
+	if role.Spec.Weight != nil {
+		sts.ObjectMeta.Annotations["tarantool.io/replicaset-weight"] = strconv.Itoa(*role.Spec.Weight)
+	}
+	if role.Spec.VshardGroup != nil {
+		sts.ObjectMeta.Labels["tarantool.io/useVshardGroups"] = "1"
+		sts.ObjectMeta.Labels["tarantool.io/vshardGroupName"] = *role.Spec.VshardGroup
+		sts.Spec.Template.Labels["tarantool.io/useVshardGroups"] = "1"
+		sts.Spec.Template.Labels["tarantool.io/vshardGroupName"] = *role.Spec.VshardGroup
+	}
+}
+
+// applyPodOverlay merges a Role's PodOverlay into sts's pod template. Env, envFrom, and
+// volume mounts are appended to rs's main container (see ReplicasetTemplate.
+// MainContainerIndex), not assumed to be Containers[0]; volumes and sidecars are
+// appended to the pod spec; NodeSelector, Tolerations, and Affinity replace the
+// template's values when set on the overlay.
+func applyPodOverlay(sts *appsv1.StatefulSet, rs *tarantooliov1alpha1.ReplicasetTemplate, overlay *tarantooliov1alpha1.PodOverlay) {
+	if overlay == nil {
+		return
+	}
+
+	podSpec := &sts.Spec.Template.Spec
+	if idx, ok := rs.MainContainerIndex(); ok {
+		podSpec.Containers[idx].Env = append(podSpec.Containers[idx].Env, overlay.ExtraEnv...)
+		podSpec.Containers[idx].EnvFrom = append(podSpec.Containers[idx].EnvFrom, overlay.ExtraEnvFrom...)
+		podSpec.Containers[idx].VolumeMounts = append(podSpec.Containers[idx].VolumeMounts, overlay.ExtraVolumeMounts...)
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, overlay.ExtraVolumes...)
+	podSpec.Containers = append(podSpec.Containers, overlay.ExtraContainers...)
+
+	if overlay.NodeSelector != nil {
+		podSpec.NodeSelector = overlay.NodeSelector
+	}
+	if overlay.Tolerations != nil {
+		podSpec.Tolerations = overlay.Tolerations
+	}
+	if overlay.Affinity != nil {
+		podSpec.Affinity = overlay.Affinity
+	}
+}
+
+// applyRoleScheduling translates sts's tarantool.io/rolesToAssign roles into node
+// affinity and tolerations per policy, appending the tolerations and overwriting the
+// pod spec's Affinity. It's a no-op, leaving whatever a PodOverlay set untouched, when
+// policy is nil, Off, or sts has no rolesToAssign roles.
+func applyRoleScheduling(sts *appsv1.StatefulSet, policy *tarantooliov1alpha1.RoleSchedulingPolicy) {
+	roles, err := topology.GetRoles(&sts.Spec.Template)
+	if err != nil {
+		return
+	}
+
+	affinity, tolerations := topology.BuildRoleScheduling(roles, policy)
+	if affinity == nil {
+		return
+	}
+
+	sts.Spec.Template.Spec.Affinity = affinity
+	sts.Spec.Template.Spec.Tolerations = append(sts.Spec.Template.Spec.Tolerations, tolerations...)
+}
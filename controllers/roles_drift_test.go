@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	"github.com/tarantool/tarantool-operator/controllers/topology"
+)
+
+func newRolesDriftScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = tarantooliov1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+// rolesDriftStatefulSet returns a StatefulSet runMaintenanceRolesDrift will consider:
+// it carries a replicaset-uuid label and a rolesToAssign label naming roles.
+func rolesDriftStatefulSet(name string, roles ...string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels: map[string]string{
+				"tarantool.io/replicaset-uuid": name + "-uuid",
+				"tarantool.io/rolesToAssign":   strings.Join(roles, "."),
+			},
+		},
+	}
+}
+
+// newRolesDriftTopologyServer serves the getReplicasetRolesQuery/editReplicasetRoles
+// GraphQL requests runMaintenanceRolesDrift issues: it reports actualRoles for every
+// GetReplicasetRolesFromService call, and either accepts or fails an
+// editReplicaset/SetReplicasetRoles call depending on failSet.
+func newRolesDriftTopologyServer(t *testing.T, actualRoles []string, failSet bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("reading request body: %s", err)
+		}
+
+		var req struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("unmarshaling request: %s", err)
+		}
+
+		switch {
+		case strings.Contains(req.Query, "editReplicasetRoles"):
+			if failSet {
+				_, _ = io.WriteString(w, `{"errors":[{"message":"edit_config failed","class_name":"PatchError"}]}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"data":{"editReplicaset":{"uuid":"x","roles":["`+strings.Join(actualRoles, `","`)+`"]}}}`)
+		case strings.Contains(req.Query, "replicasetRoles"):
+			_, _ = io.WriteString(w, `{"data":{"replicasets":[{"uuid":"x","roles":["`+strings.Join(actualRoles, `","`)+`"]}]}}`)
+		default:
+			t.Fatalf("unexpected GraphQL query: %s", req.Query)
+		}
+	}))
+}
+
+func TestRunMaintenanceRolesDrift_CorrectsDrift(t *testing.T) {
+	srv := newRolesDriftTopologyServer(t, []string{"storage"}, false)
+	defer srv.Close()
+
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	r := &ClusterReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(newRolesDriftScheme()).WithObjects(cluster).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	stsList := &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{*rolesDriftStatefulSet("sts-a", "storage", "router")}}
+	topologyClient := topology.NewBuiltInTopologyService(topology.WithTopologyEndpoint(srv.URL))
+
+	r.runMaintenanceRolesDrift(context.TODO(), cluster, stsList, topologyClient)
+
+	found := false
+	for _, cond := range cluster.Status.Conditions {
+		if cond.Type == tarantooliov1alpha1.RolesReconciled && cond.Status == tarantooliov1alpha1.ConditionTrue {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected RolesReconciled=True condition after correcting drift, got %+v", cluster.Status.Conditions)
+	}
+
+	select {
+	case evt := <-r.Recorder.(*record.FakeRecorder).Events:
+		if !strings.Contains(evt, "TopologyDriftCorrected") {
+			t.Fatalf("expected a TopologyDriftCorrected event, got %q", evt)
+		}
+	default:
+		t.Fatalf("expected a recorded event, got none")
+	}
+}
+
+func TestRunMaintenanceRolesDrift_NoDriftIsANoop(t *testing.T) {
+	srv := newRolesDriftTopologyServer(t, []string{"storage", "router"}, false)
+	defer srv.Close()
+
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	r := &ClusterReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(newRolesDriftScheme()).WithObjects(cluster).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	stsList := &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{*rolesDriftStatefulSet("sts-a", "storage", "router")}}
+	topologyClient := topology.NewBuiltInTopologyService(topology.WithTopologyEndpoint(srv.URL))
+
+	r.runMaintenanceRolesDrift(context.TODO(), cluster, stsList, topologyClient)
+
+	if len(cluster.Status.Conditions) != 0 {
+		t.Fatalf("expected no conditions recorded when roles haven't drifted, got %+v", cluster.Status.Conditions)
+	}
+
+	select {
+	case evt := <-r.Recorder.(*record.FakeRecorder).Events:
+		t.Fatalf("expected no recorded event when roles haven't drifted, got %q", evt)
+	default:
+	}
+}
+
+func TestRunMaintenanceRolesDrift_SetReplicasetRolesErrorIsRecordedAndSkipped(t *testing.T) {
+	srv := newRolesDriftTopologyServer(t, []string{"storage"}, true)
+	defer srv.Close()
+
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	r := &ClusterReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(newRolesDriftScheme()).WithObjects(cluster).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	stsList := &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{*rolesDriftStatefulSet("sts-a", "storage", "router")}}
+	topologyClient := topology.NewBuiltInTopologyService(topology.WithTopologyEndpoint(srv.URL))
+
+	r.runMaintenanceRolesDrift(context.TODO(), cluster, stsList, topologyClient)
+
+	for _, cond := range cluster.Status.Conditions {
+		if cond.Type == tarantooliov1alpha1.RolesReconciled {
+			t.Fatalf("expected no RolesReconciled condition when SetReplicasetRoles fails, got %+v", cond)
+		}
+	}
+
+	select {
+	case evt := <-r.Recorder.(*record.FakeRecorder).Events:
+		if !strings.Contains(evt, "TopologyDriftCorrectionFailed") {
+			t.Fatalf("expected a TopologyDriftCorrectionFailed event, got %q", evt)
+		}
+	default:
+		t.Fatalf("expected a recorded event, got none")
+	}
+}
+
+func TestStringSetsEqual(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{"both empty", nil, nil, true},
+		{"same order", []string{"storage", "router"}, []string{"storage", "router"}, true},
+		{"different order", []string{"storage", "router"}, []string{"router", "storage"}, true},
+		{"different length", []string{"storage"}, []string{"storage", "router"}, false},
+		{"duplicate counts differ", []string{"storage", "storage"}, []string{"storage", "router"}, false},
+	}
+
+	for _, c := range cases {
+		if got := stringSetsEqual(c.a, c.b); got != c.want {
+			t.Errorf("%s: stringSetsEqual(%v, %v) = %v, want %v", c.name, c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	"github.com/tarantool/tarantool-operator/controllers/topology"
+)
+
+// SyncRolesDrift re-asserts the Cartridge roles topology for the Cluster named by key
+// against its current StatefulSets, independent of that Cluster's own Maintenance
+// window. It satisfies pkg/controller/sync.Func, the manager-wide scheduled
+// counterpart to the per-Cluster MaintenanceOperationRolesDrift window
+// reconcileMaintenance already runs through runMaintenanceRolesDrift.
+func (r *ClusterReconciler) SyncRolesDrift(ctx context.Context, key types.NamespacedName) error {
+	cluster := &tarantooliov1alpha1.Cluster{}
+	if err := r.Get(ctx, key, cluster); err != nil {
+		return fmt.Errorf("getting Cluster %s: %w", key, err)
+	}
+
+	if cluster.Status.Leader == nil || cluster.Status.Leader.Pod == "" {
+		// No leader elected yet; the per-Cluster reconcile loop hasn't caught up to this
+		// Cluster, and there's no topology endpoint to talk to until it does.
+		return nil
+	}
+
+	clusterSelector, err := metav1.LabelSelectorAsSelector(cluster.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("cluster %s spec.selector: %w", key, err)
+	}
+
+	stsList := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, stsList, &client.ListOptions{LabelSelector: clusterSelector, Namespace: key.Namespace}); err != nil {
+		return fmt.Errorf("listing StatefulSets for Cluster %s: %w", key, err)
+	}
+
+	authOpts, err := r.buildTopologyOptions(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("building topology options for Cluster %s: %w", key, err)
+	}
+
+	scheme := "http"
+	if cluster.Spec.Auth != nil && cluster.Spec.Auth.TLS != nil {
+		scheme = "https"
+	}
+
+	topologyClient := topology.NewBuiltInTopologyService(append([]topology.Option{
+		topology.WithTopologyEndpoint(fmt.Sprintf("%s://%s/admin/api", scheme, cluster.Status.Leader.Pod)),
+		topology.WithClusterID(cluster.GetName()),
+	}, authOpts...)...)
+
+	r.runMaintenanceRolesDrift(ctx, cluster, stsList, topologyClient)
+	return nil
+}
@@ -1,7 +1,10 @@
 package tarantool
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 )
@@ -11,6 +14,59 @@ const (
 	instanceExpelling = "expelling"
 )
 
+// InstanceState is the pod condition type MarkJoined/MarkExpelling transition, so
+// `kubectl get pods -o wide` surfaces the same instance state as the
+// tarantool.io/instance-state label without requiring a custom-columns query.
+const InstanceState corev1.PodConditionType = "tarantool.io/InstanceState"
+
+// setInstanceStateCondition sets or updates p's InstanceState condition to reason,
+// bumping LastTransitionTime only when the reason actually changes.
+func setInstanceStateCondition(p *corev1.Pod, reason string) {
+	now := metav1.Now()
+	for i := range p.Status.Conditions {
+		cond := &p.Status.Conditions[i]
+		if cond.Type != InstanceState {
+			continue
+		}
+		if cond.Reason != reason {
+			cond.Reason = reason
+			cond.LastTransitionTime = now
+		}
+		cond.Status = corev1.ConditionTrue
+		return
+	}
+
+	p.Status.Conditions = append(p.Status.Conditions, corev1.PodCondition{
+		Type:               InstanceState,
+		Status:             corev1.ConditionTrue,
+		Reason:             reason,
+		LastTransitionTime: now,
+	})
+}
+
+// FailedToJoin reports whether p has held its tarantool.io/instance-uuid label for
+// longer than ttl without joining. It reads the start of that wait from p's
+// tarantool.io/join-started-at annotation rather than p.CreationTimestamp, so a pod
+// restart (which doesn't reset the annotation) doesn't also reset the TTL clock. A pod
+// that never got the annotation (or is already joined) can't have failed to join.
+func FailedToJoin(p *corev1.Pod, ttl time.Duration) bool {
+	if IsJoined(p) {
+		return false
+	}
+
+	startedAt, ok := p.GetAnnotations()["tarantool.io/join-started-at"]
+	if !ok {
+		return false
+	}
+
+	since, err := time.Parse(time.RFC3339, startedAt)
+	if err != nil {
+		return false
+	}
+
+	return time.Since(since) > ttl
+}
+
 // IsJoined .
 func IsJoined(p *corev1.Pod) bool {
 	podLabels := p.GetLabels()
@@ -36,6 +92,8 @@ func MarkJoined(p *corev1.Pod) {
 	}
 	podLabels["tarantool.io/instance-state"] = instanceJoined
 	p.SetLabels(podLabels)
+
+	setInstanceStateCondition(p, "Joined")
 }
 
 // JoinedSelector .
@@ -75,6 +133,21 @@ func MarkExpelling(p *corev1.Pod) {
 	}
 	podLabels["tarantool.io/instance-state"] = instanceExpelling
 	p.SetLabels(podLabels)
+
+	setInstanceStateCondition(p, "Expelling")
+}
+
+// MarkJoinFailed stamps p with the tarantool.io/join-deadline-exceeded label and an
+// InstanceState condition reporting it, for a pod FailedToJoin has given up waiting on.
+func MarkJoinFailed(p *corev1.Pod) {
+	podLabels := p.GetLabels()
+	if podLabels == nil {
+		podLabels = make(map[string]string)
+	}
+	podLabels["tarantool.io/join-deadline-exceeded"] = "1"
+	p.SetLabels(podLabels)
+
+	setInstanceStateCondition(p, "JoinFailed")
 }
 
 // ExpellingSelector .
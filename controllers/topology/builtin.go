@@ -0,0 +1,1371 @@
+package topology
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+	cartridgegraphql "github.com/tarantool/tarantool-operator/pkg/cartridge/graphql"
+)
+
+// ResponseError .
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// JoinResponseData .
+type JoinResponseData struct {
+	JoinInstance bool `json:"joinInstanceResponse"`
+}
+
+// ExpelResponseData .
+type ExpelResponseData struct {
+	ExpelInstance bool `json:"expel_instance"`
+}
+
+// ExpelResponse .
+type ExpelResponse struct {
+	Errors []*ResponseError   `json:"errors,omitempty"`
+	Data   *ExpelResponseData `json:"data,omitempty"`
+}
+
+// BootstrapVshardData .
+type BootstrapVshardData struct {
+	BootstrapVshard bool `json:"bootstrapVshardResponse"`
+}
+
+// BootstrapVshardResponse .
+type BootstrapVshardResponse struct {
+	Data   *BootstrapVshardData `json:"data,omitempty"`
+	Errors []*ResponseError     `json:"errors,omitempty"`
+}
+
+// EditReplicasetResponse .
+type EditReplicasetResponse struct {
+	Response bool `json:"editReplicasetResponse"`
+}
+
+// GetServerStatResponse .
+type GetServerStatResponse struct {
+	Data   *ServerStatData  `json:"data"`
+	Errors []*ResponseError `json:"errors,omitempty"`
+}
+
+// ServerStatData .
+type ServerStatData struct {
+	Stats []*ServerStat `json:"serverStat"`
+}
+
+// ServerStat .
+type ServerStat struct {
+	Statistics Statistics `json:"statistics"`
+	UUID       string     `json:"uuid"`
+	URI        string     `json:"uri"`
+}
+
+// Statistics .
+type Statistics struct {
+	ItemsUsedRatio string `json:"items_used_ratio"`
+	ArenaUsedRatio string `json:"arena_used_ratio"`
+	QuotaSize      int    `json:"quotaSize"`
+	ArenaUsed      int    `json:"arenaUsed"`
+	QuotaUsedRatio string `json:"quota_used_ratio"`
+	BucketsCount   int    `json:"bucketsCount"`
+}
+
+// VshardGroup is one entry of GetVshardGroups, naming a vshard storage group Cartridge
+// knows about (e.g. "default", "hot", "cold") and its configured bucket count.
+type VshardGroup struct {
+	Name        string `json:"name"`
+	BucketCount int    `json:"bucket_count"`
+}
+
+// getVshardGroupsResponseData .
+type getVshardGroupsResponseData struct {
+	VshardGroups []*VshardGroup `json:"vshardGroups"`
+}
+
+// setReplicasetRolesResponseData .
+type setReplicasetRolesResponseData struct {
+	EditReplicaset *editReplicasetRolesResult `json:"editReplicaset"`
+}
+
+type editReplicasetRolesResult struct {
+	UUID  string   `json:"uuid"`
+	Roles []string `json:"roles"`
+}
+
+// replicasetRolesResponseData .
+type replicasetRolesResponseData struct {
+	Replicasets []*replicasetRoles `json:"replicasets"`
+}
+
+type replicasetRoles struct {
+	UUID  string   `json:"uuid"`
+	Roles []string `json:"roles"`
+}
+
+// failoverParams .
+type failoverParams struct {
+	Mode          string `json:"mode"`
+	StateProvider string `json:"state_provider"`
+}
+
+// setFailoverResponseData .
+type setFailoverResponseData struct {
+	Cluster *setFailoverClusterData `json:"cluster"`
+}
+
+type setFailoverClusterData struct {
+	FailoverParams *failoverParams `json:"failover_params"`
+}
+
+// getFailoverResponseData .
+type getFailoverResponseData struct {
+	Cluster *getFailoverClusterData `json:"cluster"`
+}
+
+type getFailoverClusterData struct {
+	FailoverParams *failoverParams `json:"failover_params"`
+}
+
+// FailoverMode is a Cartridge cluster-wide failover mode, mirroring
+// tarantooliov1alpha1.FailoverMode without importing the CRD's own empty-means-default
+// semantics into this package's GraphQL layer.
+type FailoverMode string
+
+const (
+	FailoverModeDisabled FailoverMode = "disabled"
+	FailoverModeEventual FailoverMode = "eventual"
+	FailoverModeStateful FailoverMode = "stateful"
+)
+
+// FailoverStateProvider selects the backing store a FailoverModeStateful coordinator
+// stores its leader lock in.
+type FailoverStateProvider string
+
+const (
+	FailoverStateProviderETCD2      FailoverStateProvider = "etcd2"
+	FailoverStateProviderStateboard FailoverStateProvider = "tarantool"
+)
+
+// ETCD2FailoverParams configures Cartridge's etcd2 stateful failover coordinator.
+type ETCD2FailoverParams struct {
+	Endpoints []string
+	Prefix    string
+	LockDelay float64
+	Username  string
+	Password  string
+}
+
+// StateboardFailoverParams configures Cartridge's stateboard stateful failover
+// coordinator.
+type StateboardFailoverParams struct {
+	URI      string
+	Password string
+}
+
+// FailoverConfig is the failover configuration issued to Cartridge's
+// cluster{failover_params(...)} mutation.
+type FailoverConfig struct {
+	Mode          FailoverMode
+	StateProvider FailoverStateProvider
+	ETCD2         *ETCD2FailoverParams
+	Stateboard    *StateboardFailoverParams
+}
+
+// FailoverStatus reports the failover configuration Cartridge confirmed as active.
+type FailoverStatus struct {
+	Mode          FailoverMode
+	StateProvider FailoverStateProvider
+}
+
+// etcd2ParamsInput is the shape of Cartridge's FailoverStateProviderCfgInputEtcd2 input.
+type etcd2ParamsInput struct {
+	Prefix    string   `json:"prefix,omitempty"`
+	LockDelay float64  `json:"lock_delay,omitempty"`
+	Endpoints []string `json:"endpoints,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	Password  string   `json:"password,omitempty"`
+}
+
+// tarantoolParamsInput is the shape of Cartridge's FailoverStateProviderCfgInputTarantool input.
+type tarantoolParamsInput struct {
+	URI      string `json:"uri,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// snapshotResponseData .
+type snapshotResponseData struct {
+	Snapshot bool `json:"snapshot"`
+}
+
+// configSection is the shape of one entry in Cartridge's `cluster { config_sections }`
+// query and `cluster { edit_config(sections: ...) }` mutation: a named section of
+// clusterwide configuration (a vshard group, a role's own config, ...) and its raw
+// content.
+type configSection struct {
+	Filename string `json:"filename"`
+	Content  string `json:"content"`
+}
+
+// getConfigResponseData .
+type getConfigResponseData struct {
+	Cluster *getConfigClusterData `json:"cluster"`
+}
+
+type getConfigClusterData struct {
+	ConfigSections []*configSection `json:"config_sections"`
+}
+
+// editConfigResponseData .
+type editConfigResponseData struct {
+	Cluster *editConfigClusterData `json:"cluster"`
+}
+
+type editConfigClusterData struct {
+	EditConfig []*configSection `json:"edit_config"`
+}
+
+// BuiltInTopologyService talks to a Cartridge cluster's built-in admin/topology API.
+type BuiltInTopologyService struct {
+	serviceHost string
+	clusterID   string
+
+	username    string
+	password    string
+	token       string
+	tlsConfig   *tls.Config
+	transport   http.RoundTripper
+	timeout     time.Duration
+	jar         http.CookieJar
+	retryPolicy RetryPolicy
+
+	clientOnce sync.Once
+	client     *http.Client
+
+	gqlClientOnce sync.Once
+	gqlClient     *cartridgegraphql.Client
+}
+
+var log = logf.Log.WithName("topology")
+
+var (
+	errTopologyIsDown      = errors.New("topology service is down")
+	errAlreadyJoined       = errors.New("already joined")
+	errAlreadyBootstrapped = errors.New("already bootstrapped")
+	errAlreadyExpelled     = errors.New("already expelled")
+	errServerDead          = errors.New("server is dead")
+)
+
+var joinMutation = `mutation
+	do_join_server(
+		$uri: String!,
+		$instance_uuid: String!,
+		$replicaset_uuid: String!,
+		$roles: [String!],
+		$vshard_group: String!
+	) {
+	joinInstanceResponse: join_server(
+		uri: $uri,
+		instance_uuid: $instance_uuid,
+		replicaset_uuid: $replicaset_uuid,
+		roles: $roles,
+		timeout: 10,
+		vshard_group: $vshard_group
+	)
+}`
+
+var editRsMutation = `mutation editReplicaset(
+	$uuid: String!,
+	$weight: Float,
+	$vshard_group: String,
+	$all_rw: Boolean,
+	$roles: [String!],
+	$alias: String
+) {
+	editReplicasetResponse: edit_replicaset(
+		uuid: $uuid,
+		weight: $weight,
+		vshard_group: $vshard_group,
+		all_rw: $all_rw,
+		roles: $roles,
+		alias: $alias
+	)
+}`
+
+var bootstrapVshardGroupMutation = `mutation bootstrapVshardGroup($name: String!) {
+	bootstrapVshardResponse: bootstrap_vshard(group_name: $name)
+}`
+
+var getVshardGroupsQuery = `query vshardGroups {
+	vshardGroups: vshard_groups {
+		name
+		bucket_count
+	}
+}`
+
+var setReplicasetRolesMutation = `mutation editReplicasetRoles($uuid: String!, $roles: [String!]) {
+	editReplicaset(uuid: $uuid, roles: $roles) {
+		uuid
+		roles
+	}
+}`
+
+var getReplicasetRolesQuery = `query replicasetRoles($uuid: String!) {
+	replicasets(uuid: $uuid) {
+		uuid
+		roles
+	}
+}`
+
+var getServerStatQuery = `query serverList {
+	serverStat: servers {
+		uuid
+		uri
+		statistics {
+			quotaSize: quota_size
+			arenaUsed: arena_used
+			bucketsCount: vshard_buckets_count
+			quota_used_ratio
+			arena_used_ratio
+			items_used_ratio
+		}
+	}
+}`
+
+var setFailoverMutation = `mutation setFailoverMode(
+	$mode: String!,
+	$state_provider: String,
+	$etcd2_params: FailoverStateProviderCfgInputEtcd2,
+	$tarantool_params: FailoverStateProviderCfgInputTarantool
+) {
+	cluster {
+		failover_params(
+			mode: $mode,
+			state_provider: $state_provider,
+			etcd2_params: $etcd2_params,
+			tarantool_params: $tarantool_params
+		) {
+			mode
+			state_provider
+		}
+	}
+}`
+
+var getFailoverQuery = `query {
+	cluster {
+		failover_params {
+			mode
+			state_provider
+		}
+	}
+}`
+
+var snapshotMutation = `mutation { snapshot: make_snapshot }`
+
+var getConfigQuery = `query getConfig($sections: [String!]) {
+	cluster {
+		config_sections(sections: $sections) {
+			filename
+			content
+		}
+	}
+}`
+
+var editConfigMutation = `mutation editConfig($sections: [ConfigSectionInput!]) {
+	cluster {
+		edit_config(sections: $sections) {
+			filename
+			content
+		}
+	}
+}`
+
+// GetRoles reads the `tarantool.io/rolesToAssign` annotation or label off o, which may
+// be a Pod or a StatefulSet, and returns the roles it names.
+func GetRoles(o metav1.Object) ([]string, error) {
+	thisLabels := o.GetLabels()
+	thisAnnotations := o.GetAnnotations()
+
+	rolesFromAnnotations, ok := thisAnnotations["tarantool.io/rolesToAssign"]
+	if !ok {
+		rolesFromLabels, ok := thisLabels["tarantool.io/rolesToAssign"]
+		if !ok {
+			return nil, errors.New("role undefined")
+		}
+
+		roles := strings.Split(rolesFromLabels, ".")
+		log.Info("roles", "roles", roles)
+
+		return roles, nil
+	}
+
+	var singleRole string
+	var roleArray []string
+
+	err := json.Unmarshal([]byte(rolesFromAnnotations), &singleRole)
+	if err == nil {
+		log.Info("roles", "roles", singleRole)
+		return []string{singleRole}, nil
+	}
+
+	err = json.Unmarshal([]byte(rolesFromAnnotations), &roleArray)
+	if err == nil {
+		log.Info("roles", "roles", roleArray)
+		return roleArray, nil
+	}
+
+	return nil, errors.New("failed to parse roles from annotations")
+}
+
+// defaultRoleTaintKey is used when a RoleSchedulingPolicy doesn't set TaintKey.
+const defaultRoleTaintKey = "tarantool.io/role"
+
+// defaultRoleSchedulingWeight is used when a RoleSchedulingPolicy doesn't set Weight.
+const defaultRoleSchedulingWeight = 1
+
+// BuildRoleScheduling translates roles (as returned by GetRoles) into the node affinity
+// and tolerations needed to land a pod on nodes provisioned for them, per policy: each
+// role becomes a toleration for taint "<TaintKey>=<role>:NoSchedule" plus a
+// nodeAffinity term requiring/preferring a node labeled "<TaintKey> in (<roles...>)".
+// It returns (nil, nil) if policy is nil, policy.Mode is Off or empty, or roles is
+// empty, so callers can apply the result unconditionally.
+func BuildRoleScheduling(roles []string, policy *tarantooliov1alpha1.RoleSchedulingPolicy) (*corev1.Affinity, []corev1.Toleration) {
+	if policy == nil || len(roles) == 0 {
+		return nil, nil
+	}
+	if policy.Mode != tarantooliov1alpha1.RoleSchedulingRequired && policy.Mode != tarantooliov1alpha1.RoleSchedulingPreferred {
+		return nil, nil
+	}
+
+	taintKey := policy.TaintKey
+	if taintKey == "" {
+		taintKey = defaultRoleTaintKey
+	}
+
+	tolerations := make([]corev1.Toleration, 0, len(roles))
+	for _, role := range roles {
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      taintKey,
+			Operator: corev1.TolerationOpEqual,
+			Value:    role,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	}
+
+	term := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{
+				Key:      taintKey,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   roles,
+			},
+		},
+	}
+
+	nodeAffinity := &corev1.NodeAffinity{}
+	if policy.Mode == tarantooliov1alpha1.RoleSchedulingRequired {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{term},
+		}
+	} else {
+		weight := policy.Weight
+		if weight == 0 {
+			weight = defaultRoleSchedulingWeight
+		}
+		nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []corev1.PreferredSchedulingTerm{
+			{
+				Weight:     weight,
+				Preference: term,
+			},
+		}
+	}
+
+	return &corev1.Affinity{NodeAffinity: nodeAffinity}, tolerations
+}
+
+// httpClient returns the *http.Client shared by every request this service makes,
+// building it once (whichever of NewBuiltInTopologyService or a direct struct literal,
+// as tests use, constructed s) from whatever TLS config, credentials, and transport were
+// set via the With* options, so repeated calls reuse the same pooled connections and
+// Cartridge session cookie instead of renegotiating both on every request.
+// s.transport (set via WithPortForwardTransport or WithRoundTripper) takes precedence
+// over s.tlsConfig, since a custom transport is responsible for its own dialing and TLS.
+func (s *BuiltInTopologyService) httpClient() *http.Client {
+	s.clientOnce.Do(func() {
+		var transport http.RoundTripper = http.DefaultTransport
+		if s.tlsConfig != nil {
+			transport = &http.Transport{TLSClientConfig: s.tlsConfig}
+		}
+		if s.transport != nil {
+			transport = s.transport
+		}
+
+		switch {
+		case s.token != "":
+			transport = &authRoundTripper{base: transport, token: s.token}
+		case s.username != "":
+			jar := s.jar
+			if jar == nil {
+				jar, _ = cookiejar.New(nil)
+			}
+			transport = &sessionRoundTripper{
+				base:     transport,
+				jar:      jar,
+				loginURL: s.serviceHost + "/login",
+				username: s.username,
+				password: s.password,
+			}
+		}
+
+		timeout := s.timeout
+		if timeout == 0 {
+			timeout = 5 * time.Second
+		}
+
+		s.client = &http.Client{Timeout: timeout, Transport: transport}
+	})
+
+	return s.client
+}
+
+// TopologyError is a structured error from one entry of a Cartridge GraphQL response's
+// errors[] array, built from its message and extensions object. It replaces
+// substring-matching on err.Error() (fragile across Cartridge versions and
+// localizations) as the basis for sentinel checks like IsAlreadyJoined and IsRetryable.
+// It's an alias for cartridgegraphql.Error so a *TopologyError test or call site built
+// before this package started delegating its requests to cartridgegraphql.Client keeps
+// compiling unchanged.
+type TopologyError = cartridgegraphql.Error
+
+// RetryPolicy configures withRetry's exponential backoff. It's an alias for
+// cartridgegraphql.RetryPolicy, which now owns the retry loop this service's requests
+// run through.
+type RetryPolicy = cartridgegraphql.RetryPolicy
+
+// defaultRetryPolicy is used by withRetry when a BuiltInTopologyService wasn't given a
+// RetryPolicy via WithRetryPolicy.
+var defaultRetryPolicy = cartridgegraphql.DefaultRetryPolicy
+
+// graphQLClient lazily builds the cartridgegraphql.Client every request this service
+// makes is routed through, reusing s.httpClient() so the session cookie/bearer token
+// and connection pool it manages are shared rather than duplicated.
+func (s *BuiltInTopologyService) graphQLClient() *cartridgegraphql.Client {
+	s.gqlClientOnce.Do(func() {
+		s.gqlClient = cartridgegraphql.NewClient(s.serviceHost, s.httpClient(), s.retryPolicy)
+	})
+
+	return s.gqlClient
+}
+
+// doGraphQL POSTs a GraphQL query/variables pair to the Cartridge admin/topology API
+// and decodes the result into out (skipped if nil or the response carried no data). A
+// Cartridge-reported error comes back as a *TopologyError built from errors[0], rather
+// than the opaque "graphql: <message>" string machinebox/graphql.Client.Run would
+// otherwise return.
+func (s *BuiltInTopologyService) doGraphQL(query string, variables map[string]interface{}, out interface{}) error {
+	return s.graphQLClient().Do(query, variables, out)
+}
+
+// withRetry runs op, retrying with exponential backoff on errors IsRetryable
+// classifies as transient (the admin endpoint unreachable, topology not yet
+// bootstrapped, a config patch already in flight), and returning immediately on any
+// other error so a terminal failure like "already joined" isn't needlessly retried.
+// This keeps many pods Join-ing a booting leader at once from turning into a
+// thundering herd of identical failed requests.
+func (s *BuiltInTopologyService) withRetry(op func() error) error {
+	return cartridgegraphql.WithRetry(s.retryPolicy, op)
+}
+
+// authRoundTripper injects a configured bearer token into every request a
+// BuiltInTopologyService makes, whether issued via the graphql client or raw http.Post.
+type authRoundTripper struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+
+	return t.base.RoundTrip(req)
+}
+
+// sessionRoundTripper authenticates against Cartridge's cookie-based admin auth: it
+// POSTs username/password to serviceHost+"/login" the first time it's needed, caching
+// the lsid cookie Cartridge sets in jar, and attaches that cookie to every subsequent
+// request. A request that comes back 401 (the session expired, or Cartridge restarted
+// and forgot it) triggers exactly one re-login and retry before the error is returned
+// to the caller.
+type sessionRoundTripper struct {
+	base     http.RoundTripper
+	jar      http.CookieJar
+	loginURL string
+	username string
+	password string
+
+	mu          sync.Mutex
+	established bool
+}
+
+// login POSTs to loginURL and relies on jar (shared with the outer *http.Client's
+// transport) to capture the lsid cookie from the response.
+func (t *sessionRoundTripper) login() error {
+	form := url.Values{"username": {t.username}, "password": {t.password}}
+	req, err := http.NewRequest(http.MethodPost, t.loginURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := (&http.Client{Transport: t.base, Jar: t.jar}).Do(req)
+	if err != nil {
+		return fmt.Errorf("cartridge login: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("cartridge login failed: %s", resp.Status)
+	}
+
+	t.established = true
+
+	return nil
+}
+
+func (t *sessionRoundTripper) attachCookies(req *http.Request) {
+	for _, c := range t.jar.Cookies(req.URL) {
+		req.AddCookie(c)
+	}
+}
+
+func (t *sessionRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	if !t.established {
+		if err := t.login(); err != nil {
+			t.mu.Unlock()
+			return nil, err
+		}
+	}
+	t.mu.Unlock()
+
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		if bodyBytes, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	attempt := req.Clone(req.Context())
+	if bodyBytes != nil {
+		attempt.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+	}
+	t.attachCookies(attempt)
+
+	resp, err := t.base.RoundTrip(attempt)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	t.mu.Lock()
+	t.established = false
+	loginErr := t.login()
+	t.mu.Unlock()
+	if loginErr != nil {
+		return nil, loginErr
+	}
+
+	retry := req.Clone(req.Context())
+	if bodyBytes != nil {
+		retry.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+	}
+	t.attachCookies(retry)
+
+	return t.base.RoundTrip(retry)
+}
+
+// Join comment
+func (s *BuiltInTopologyService) Join(pod *corev1.Pod) error {
+
+	advURI := fmt.Sprintf("%s.%s.%s.svc.cluster.local:3301", pod.GetObjectMeta().GetName(), s.clusterID, pod.GetObjectMeta().GetNamespace())
+
+	thisPodLabels := pod.GetLabels()
+
+	replicasetUUID, ok := thisPodLabels["tarantool.io/replicaset-uuid"]
+	if !ok {
+		return errors.New("replicaset uuid empty")
+	}
+
+	log.Info("payload", "advURI", advURI, "replicasetUUID", replicasetUUID)
+
+	instanceUUID, ok := thisPodLabels["tarantool.io/instance-uuid"]
+	if !ok {
+		return errors.New("instance uuid empty")
+	}
+
+	roles, err := GetRoles(pod)
+	if err != nil {
+		return err
+	}
+	log.Info("roles", "roles", roles)
+
+	vshardGroup := "default"
+	useVshardGroups, ok := thisPodLabels["tarantool.io/useVshardGroups"]
+	if !ok {
+		return errors.New("failed to get label tarantool.io/useVshardGroups")
+	}
+
+	if useVshardGroups == "1" {
+		vshardGroup, ok = thisPodLabels["tarantool.io/vshardGroupName"]
+		if !ok {
+			return errors.New("vshard_group undefined")
+		}
+	}
+
+	resp := &JoinResponseData{}
+	err = s.withRetry(func() error {
+		return s.doGraphQL(joinMutation, map[string]interface{}{
+			"uri":             advURI,
+			"instance_uuid":   instanceUUID,
+			"replicaset_uuid": replicasetUUID,
+			"roles":           roles,
+			"vshard_group":    vshardGroup,
+		}, resp)
+	})
+	if err != nil {
+		var topoErr *TopologyError
+		if errors.As(err, &topoErr) {
+			if topoErr.ClassName == "AlreadyJoined" || strings.Contains(topoErr.Message, "already joined") {
+				return errAlreadyJoined
+			}
+			if topoErr.ClassName == "NotBootstrapped" || strings.Contains(topoErr.Message, "isn't bootstrapped yet") {
+				return errTopologyIsDown
+			}
+		}
+
+		return err
+	}
+
+	if resp.JoinInstance == true {
+		return nil
+	}
+
+	return errors.New("something really bad happened")
+}
+
+// Expel removes an instance from the replicaset
+func (s *BuiltInTopologyService) Expel(pod *corev1.Pod) error {
+	resp := &ExpelResponseData{}
+	err := s.doGraphQL(
+		"mutation expel($uuid: String!) {expel_instance:expel_server(uuid:$uuid)}",
+		map[string]interface{}{"uuid": pod.GetAnnotations()["tarantool.io/instance_uuid"]},
+		resp,
+	)
+	if err != nil {
+		var topoErr *TopologyError
+		if errors.As(err, &topoErr) {
+			if topoErr.ClassName == "ServerNotFound" || strings.Contains(topoErr.Message, "does not exist") {
+				return errAlreadyExpelled
+			}
+			if topoErr.ClassName == "ServerIsDead" || strings.Contains(topoErr.Message, "is dead") {
+				return errServerDead
+			}
+		}
+
+		return err
+	}
+
+	if resp.ExpelInstance == false {
+		return errors.New("something really bad happened")
+	}
+
+	return nil
+}
+
+// EditReplicasetOptions configures a BuiltInTopologyService.EditReplicaset call. Only
+// non-nil/non-empty fields are sent to Cartridge, so a caller can patch a single
+// property (e.g. just Weight, as the original SetWeight did) without clobbering the
+// rest of the replicaset's configuration.
+type EditReplicasetOptions struct {
+	// Weight is the vshard bucket weight to assign to the replicaset.
+	Weight *float64
+	// VshardGroup assigns the replicaset to a named vshard storage group, for clusters
+	// with more than one (e.g. "hot"/"cold"). Only meaningful for a vshard-storage
+	// replicaset.
+	VshardGroup string
+	// AllRW, if set, configures whether every replica in the replicaset accepts
+	// writes, rather than just its master.
+	AllRW *bool
+	// Roles replaces the Cartridge roles assigned to the replicaset.
+	Roles []string
+	// Alias sets the replicaset's human-readable name, as shown in the Cartridge UI.
+	Alias string
+}
+
+// EditReplicaset applies opts to a replicaset via the cluster admin API's
+// edit_replicaset mutation. It replaces the original weight-only SetWeight now that
+// Cartridge's edit_replicaset also accepts vshard_group, all_rw, roles, and alias,
+// needed to assign replicasets to a named vshard group in hot/cold storage topologies.
+func (s *BuiltInTopologyService) EditReplicaset(replicasetUUID string, opts EditReplicasetOptions) error {
+	reqLogger := log.WithValues("namespace", "topology.builtin")
+	reqLogger.Info("editing replicaset", "uuid", replicasetUUID, "options", opts)
+
+	variables := map[string]interface{}{"uuid": replicasetUUID}
+	if opts.Weight != nil {
+		variables["weight"] = *opts.Weight
+	}
+	if opts.VshardGroup != "" {
+		variables["vshard_group"] = opts.VshardGroup
+	}
+	if opts.AllRW != nil {
+		variables["all_rw"] = *opts.AllRW
+	}
+	if len(opts.Roles) > 0 {
+		variables["roles"] = opts.Roles
+	}
+	if opts.Alias != "" {
+		variables["alias"] = opts.Alias
+	}
+
+	resp := &EditReplicasetResponse{}
+	if err := s.doGraphQL(editRsMutation, variables, resp); err != nil {
+		return err
+	}
+
+	if resp.Response == true {
+		return nil
+	}
+
+	return errors.New("something really bad happened")
+}
+
+// SetReplicasetRoles sets the vshard roles assigned to a replicaset
+func (s *BuiltInTopologyService) SetReplicasetRoles(replicasetUUID string, roles []string) error {
+	resp := &setReplicasetRolesResponseData{}
+
+	return s.doGraphQL(setReplicasetRolesMutation, map[string]interface{}{
+		"uuid":  replicasetUUID,
+		"roles": roles,
+	}, resp)
+}
+
+// GetReplicasetRolesFromService returns the vshard roles Cartridge currently has
+// assigned to a replicaset.
+func (s *BuiltInTopologyService) GetReplicasetRolesFromService(replicasetUUID string) ([]string, error) {
+	resp := &replicasetRolesResponseData{}
+	if err := s.doGraphQL(getReplicasetRolesQuery, map[string]interface{}{"uuid": replicasetUUID}, resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Replicasets) == 0 {
+		return nil, fmt.Errorf("replicaset %s not found", replicasetUUID)
+	}
+
+	return resp.Replicasets[0].Roles, nil
+}
+
+// GetServerStat Fetch the replicaset as reported by cartridge
+func (s *BuiltInTopologyService) GetServerStat() (ServerStatData, error) {
+	reqLogger := log.WithValues("function", "GetServerStat")
+
+	reqLogger.Info("fetching server stats")
+
+	resp := ServerStatData{}
+	if err := s.doGraphQL(getServerStatQuery, nil, &resp); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// BootstrapVshard enable the vshard service on the cluster
+func (s *BuiltInTopologyService) BootstrapVshard() error {
+	reqLogger := log.WithValues("namespace", "topology.builtin")
+
+	reqLogger.Info("Bootstrapping vshard")
+
+	resp := &BootstrapVshardData{}
+	err := s.withRetry(func() error {
+		return s.doGraphQL("mutation bootstrap {bootstrapVshardResponse: bootstrap_vshard}", nil, resp)
+	})
+	if err != nil {
+		var topoErr *TopologyError
+		if errors.As(err, &topoErr) && (topoErr.ClassName == "AlreadyBootstrapped" || strings.Contains(topoErr.Message, "already bootstrapped")) {
+			return errAlreadyBootstrapped
+		}
+
+		return err
+	}
+
+	if resp.BootstrapVshard {
+		return nil
+	}
+
+	return errors.New("unknown error")
+}
+
+// BootstrapVshardGroup enables vshard for a single named vshard storage group, for
+// clusters with more than one group (e.g. "hot"/"cold"), each of which Cartridge
+// requires to be bootstrapped separately rather than all at once by BootstrapVshard.
+func (s *BuiltInTopologyService) BootstrapVshardGroup(name string) error {
+	reqLogger := log.WithValues("namespace", "topology.builtin")
+	reqLogger.Info("bootstrapping vshard group", "group", name)
+
+	resp := &BootstrapVshardData{}
+	err := s.withRetry(func() error {
+		return s.doGraphQL(bootstrapVshardGroupMutation, map[string]interface{}{"name": name}, resp)
+	})
+	if err != nil {
+		var topoErr *TopologyError
+		if errors.As(err, &topoErr) && (topoErr.ClassName == "AlreadyBootstrapped" || strings.Contains(topoErr.Message, "already bootstrapped")) {
+			return errAlreadyBootstrapped
+		}
+
+		return err
+	}
+
+	if resp.BootstrapVshard {
+		return nil
+	}
+
+	return errors.New("unknown error")
+}
+
+// GetVshardGroups reports every vshard storage group Cartridge currently knows about,
+// so ClusterReconciler can bootstrap each one once its replicasets are populated
+// instead of assuming a single "default" group.
+func (s *BuiltInTopologyService) GetVshardGroups() ([]*VshardGroup, error) {
+	resp := &getVshardGroupsResponseData{}
+	if err := s.doGraphQL(getVshardGroupsQuery, nil, resp); err != nil {
+		return nil, err
+	}
+
+	return resp.VshardGroups, nil
+}
+
+// SetFailover applies cfg as Cartridge's cluster-wide failover configuration via the
+// cluster{failover_params(...)} mutation. cfg.Mode defaults to FailoverModeEventual if
+// unset. A FailoverModeStateful cfg without ETCD2 or Stateboard set is rejected before
+// any request is made, since Cartridge's own error for a missing state provider is
+// a generic "bad params" message.
+func (s *BuiltInTopologyService) SetFailover(cfg FailoverConfig) error {
+	mode := cfg.Mode
+	if mode == "" {
+		mode = FailoverModeEventual
+	}
+
+	variables := map[string]interface{}{"mode": string(mode)}
+
+	if mode == FailoverModeStateful {
+		variables["state_provider"] = string(cfg.StateProvider)
+
+		switch cfg.StateProvider {
+		case FailoverStateProviderETCD2:
+			if cfg.ETCD2 == nil {
+				return errors.New("stateful failover requires etcd2 params when state provider is etcd2")
+			}
+			variables["etcd2_params"] = etcd2ParamsInput{
+				Prefix:    cfg.ETCD2.Prefix,
+				LockDelay: cfg.ETCD2.LockDelay,
+				Endpoints: cfg.ETCD2.Endpoints,
+				Username:  cfg.ETCD2.Username,
+				Password:  cfg.ETCD2.Password,
+			}
+		case FailoverStateProviderStateboard:
+			if cfg.Stateboard == nil {
+				return errors.New("stateful failover requires stateboard params when state provider is tarantool")
+			}
+			variables["tarantool_params"] = tarantoolParamsInput{
+				URI:      cfg.Stateboard.URI,
+				Password: cfg.Stateboard.Password,
+			}
+		default:
+			return fmt.Errorf("stateful failover requires a state provider, got %q", cfg.StateProvider)
+		}
+	}
+
+	resp := &setFailoverResponseData{}
+	if err := s.withRetry(func() error {
+		return s.doGraphQL(setFailoverMutation, variables, resp)
+	}); err != nil {
+		log.Error(err, "failoverError")
+		return errors.New("failed to set cluster failover")
+	}
+
+	return nil
+}
+
+// GetFailoverStatus reports Cartridge's currently active failover configuration.
+func (s *BuiltInTopologyService) GetFailoverStatus() (*FailoverStatus, error) {
+	resp := &getFailoverResponseData{}
+	if err := s.doGraphQL(getFailoverQuery, nil, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Cluster == nil || resp.Cluster.FailoverParams == nil {
+		return nil, errors.New("malformed failover response")
+	}
+
+	return &FailoverStatus{
+		Mode:          FailoverMode(resp.Cluster.FailoverParams.Mode),
+		StateProvider: FailoverStateProvider(resp.Cluster.FailoverParams.StateProvider),
+	}, nil
+}
+
+// GetFailover reports whether cluster-wide failover is currently enabled, i.e. the
+// active mode isn't FailoverModeDisabled.
+func (s *BuiltInTopologyService) GetFailover() (bool, error) {
+	status, err := s.GetFailoverStatus()
+	if err != nil {
+		return false, err
+	}
+
+	return status.Mode != FailoverModeDisabled, nil
+}
+
+// Snapshot triggers an on-demand memtx snapshot across the cluster, for use ahead of a
+// maintenance window rather than waiting on Tarantool's own checkpoint interval.
+func (s *BuiltInTopologyService) Snapshot() error {
+	resp := &snapshotResponseData{}
+	if err := s.doGraphQL(snapshotMutation, nil, resp); err != nil {
+		log.Error(err, "snapshotError")
+		return errors.New("failed to snapshot cluster")
+	}
+
+	if !resp.Snapshot {
+		return errors.New("snapshot call returned false")
+	}
+
+	return nil
+}
+
+// maxInlineConfigSectionSize is the largest a single config section's content may be
+// before ApplyConfig routes it through uploadConfigMultipart instead of the
+// cluster{edit_config} GraphQL mutation. Cartridge's GraphQL endpoint decodes the whole
+// request body into memory before validating it, so a large section (a bundled Lua
+// module, a big vshard config) is cheaper and safer to send as a multipart upload to
+// /admin/config, which Cartridge streams to disk.
+const maxInlineConfigSectionSize = 64 * 1024
+
+// GetConfig reads sections (or every section, if sections is empty) from Cartridge's
+// clusterwide configuration via the cluster{config_sections} query, returning each
+// section's content keyed by filename.
+func (s *BuiltInTopologyService) GetConfig(sections []string) (map[string][]byte, error) {
+	resp := &getConfigResponseData{}
+	if err := s.doGraphQL(getConfigQuery, map[string]interface{}{"sections": sections}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Cluster == nil {
+		return nil, errors.New("malformed config response")
+	}
+
+	out := make(map[string][]byte, len(resp.Cluster.ConfigSections))
+	for _, section := range resp.Cluster.ConfigSections {
+		out[section.Filename] = []byte(section.Content)
+	}
+
+	return out, nil
+}
+
+// ApplyConfig pushes sections into Cartridge's clusterwide configuration via the
+// cluster{edit_config} mutation, which Cartridge itself applies with a two-phase
+// commit across every instance so a section never ends up partially applied. Any
+// section at or above maxInlineConfigSectionSize is instead sent through
+// uploadConfigMultipart, since the GraphQL endpoint buffers the whole request in
+// memory. ApplyConfig is retried on a transient (IsRetryable) error, same as Join and
+// BootstrapVshard, since many replicas can race to apply the same config on startup.
+func (s *BuiltInTopologyService) ApplyConfig(sections map[string][]byte) error {
+	inline := make([]configSection, 0, len(sections))
+	var large map[string][]byte
+
+	for filename, content := range sections {
+		if len(content) >= maxInlineConfigSectionSize {
+			if large == nil {
+				large = make(map[string][]byte)
+			}
+			large[filename] = content
+			continue
+		}
+
+		inline = append(inline, configSection{Filename: filename, Content: string(content)})
+	}
+
+	if len(inline) > 0 {
+		resp := &editConfigResponseData{}
+		if err := s.withRetry(func() error {
+			return s.doGraphQL(editConfigMutation, map[string]interface{}{"sections": inline}, resp)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for filename, content := range large {
+		if err := s.withRetry(func() error {
+			return s.uploadConfigMultipart(filename, content)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uploadConfigMultipart PUTs a single clusterwide config section to Cartridge's
+// /admin/config endpoint as a multipart/form-data upload, the path ApplyConfig falls
+// back to for sections too large to buffer through the GraphQL endpoint.
+func (s *BuiltInTopologyService) uploadConfigMultipart(filename string, content []byte) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(filename, filename)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.configUploadURL(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("uploading config section %q: %s: %s", filename, resp.Status, respBody)
+	}
+
+	return nil
+}
+
+// configUploadURL derives Cartridge's /admin/config endpoint from serviceHost, which
+// is configured as the admin/topology GraphQL endpoint (conventionally ending in
+// "/admin/api"). /admin/config is a sibling of /admin/api rather than a sub-path, so
+// the "/admin/api" suffix (if present) is replaced rather than appended to.
+func (s *BuiltInTopologyService) configUploadURL() string {
+	return strings.TrimSuffix(s.serviceHost, "/admin/api") + "/admin/config"
+}
+
+// IsTopologyDown .
+func IsTopologyDown(err error) bool {
+	return err == errTopologyIsDown
+}
+
+// IsAlreadyJoined .
+func IsAlreadyJoined(err error) bool {
+	return err == errAlreadyJoined
+}
+
+// IsAlreadyExpelled .
+func IsAlreadyExpelled(err error) bool {
+	return err == errAlreadyExpelled
+}
+
+// IsServerDead reports whether err is Cartridge reporting the target instance as dead
+// (unreachable via membership), as opposed to simply not found/already expelled.
+func IsServerDead(err error) bool {
+	return err == errServerDead
+}
+
+// IsAlreadyBootstrapped .
+func IsAlreadyBootstrapped(err error) bool {
+	return err == errAlreadyBootstrapped
+}
+
+// IsConnectionError reports whether err means the Cartridge admin endpoint itself was
+// unreachable (connection refused/reset, DNS failure, timeout), as opposed to a
+// GraphQL-level error from an endpoint that answered. ClusterReconciler treats this as
+// a sign the elected leader pod died and releases the leader Lease so the next
+// reconcile re-elects instead of waiting out the rest of the current holder's lease.
+func IsConnectionError(err error) bool {
+	if err == nil || err == errTopologyIsDown || err == errAlreadyJoined || err == errAlreadyExpelled || err == errAlreadyBootstrapped || err == errServerDead {
+		return false
+	}
+
+	return cartridgegraphql.IsConnectionError(err)
+}
+
+// IsBucketRebalancing reports whether err is Cartridge reporting that vshard is
+// currently rebalancing buckets, a condition callers typically wait out rather than
+// treat as a failure.
+func IsBucketRebalancing(err error) bool {
+	return cartridgegraphql.IsBucketRebalancing(err)
+}
+
+// IsPatchInProgress reports whether err is Cartridge rejecting a request because
+// another clusterwide config patch (e.g. a concurrent SetFailover/SetReplicasetRoles
+// call) is already being applied.
+func IsPatchInProgress(err error) bool {
+	return cartridgegraphql.IsPatchInProgress(err)
+}
+
+// IsRetryable reports whether err represents a transient condition worth retrying: the
+// admin endpoint being unreachable, Cartridge not yet bootstrapped, vshard currently
+// rebalancing buckets, or a concurrent config patch still in flight. Terminal errors
+// (already joined/expelled/bootstrapped, validation failures) return false, so
+// withRetry doesn't waste attempts on work that will never succeed.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return IsConnectionError(err) || err == errTopologyIsDown || IsBucketRebalancing(err) || IsPatchInProgress(err)
+}
+
+// Option configures a BuiltInTopologyService.
+type Option func(s *BuiltInTopologyService)
+
+// WithTopologyEndpoint .
+func WithTopologyEndpoint(url string) Option {
+	return func(s *BuiltInTopologyService) {
+		s.serviceHost = url
+	}
+}
+
+// WithClusterID .
+func WithClusterID(id string) Option {
+	return func(s *BuiltInTopologyService) {
+		s.clusterID = id
+	}
+}
+
+// WithBasicAuth configures the username/password this service logs into the Cartridge
+// admin/topology API with. The first request made after this option is set triggers
+// Cartridge's cookie-based /login handshake, caching the resulting lsid cookie and
+// re-running the handshake once if a later request comes back 401.
+func WithBasicAuth(username, password string) Option {
+	return func(s *BuiltInTopologyService) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// WithBearerToken configures a bearer token for every request this service makes to
+// the Cartridge admin/topology API. Takes precedence over WithBasicAuth.
+func WithBearerToken(token string) Option {
+	return func(s *BuiltInTopologyService) {
+		s.token = token
+	}
+}
+
+// WithTLSConfig configures the TLS client used to reach the Cartridge admin/topology
+// API over HTTPS, e.g. a custom CA pool, client certificate or InsecureSkipVerify.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *BuiltInTopologyService) {
+		s.tlsConfig = cfg
+	}
+}
+
+// WithRoundTripper overrides the transport every request is sent through, taking
+// precedence over WithTLSConfig. WithPortForwardTransport is the main use of this;
+// it's exposed directly for tests that need some other custom dialer.
+func WithRoundTripper(transport http.RoundTripper) Option {
+	return func(s *BuiltInTopologyService) {
+		s.transport = transport
+	}
+}
+
+// WithPortForwardTransport configures the service to reach pod's podPort by tunneling
+// every request through the Kubernetes API server's pods/portforward subresource
+// (see PortForwardTransport), instead of dialing the pod's IP directly. This lets the
+// operator run out-of-cluster, and lets tests reach a real pod under envtest without
+// needing pod IPs to be routable from the test process.
+//
+// WithTopologyEndpoint is still required alongside this option: PortForwardTransport
+// only rewrites the request's host, not its path, so serviceHost's path (e.g.
+// "/admin/api") is still what's requested over the tunnel.
+func WithPortForwardTransport(restConfig *rest.Config, pod types.NamespacedName, podPort int) (Option, error) {
+	transport, err := NewPortForwardTransport(restConfig, pod, podPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithRoundTripper(transport), nil
+}
+
+// WithTimeout overrides the 5-second default timeout applied to every request this
+// service makes to the Cartridge admin/topology API.
+func WithTimeout(d time.Duration) Option {
+	return func(s *BuiltInTopologyService) {
+		s.timeout = d
+	}
+}
+
+// WithCookieJar configures the http.CookieJar used to store the lsid cookie obtained
+// from WithBasicAuth's /login handshake, instead of the cookiejar.New(nil) this service
+// otherwise creates for itself. Tests use this to inspect the cookie a fake Cartridge
+// server hands back.
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(s *BuiltInTopologyService) {
+		s.jar = jar
+	}
+}
+
+// WithCACertFromSecret configures the CA pool used to verify the Cartridge
+// admin/topology API's server certificate from a Secret's "ca.crt" key, for callers
+// that would otherwise duplicate ClusterReconciler.buildTopologyOptions' own
+// pool-from-PEM handling.
+func WithCACertFromSecret(caCertPEM []byte) (Option, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCertPEM) {
+		return nil, errors.New("no certificates found in ca.crt")
+	}
+
+	return func(s *BuiltInTopologyService) {
+		if s.tlsConfig == nil {
+			s.tlsConfig = &tls.Config{}
+		}
+		s.tlsConfig.RootCAs = pool
+	}, nil
+}
+
+// WithRetryPolicy overrides defaultRetryPolicy's exponential backoff for requests this
+// service retries on a transient (IsRetryable) error.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(s *BuiltInTopologyService) {
+		s.retryPolicy = policy
+	}
+}
+
+// NewBuiltInTopologyService .
+func NewBuiltInTopologyService(opts ...Option) *BuiltInTopologyService {
+	s := &BuiltInTopologyService{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
@@ -2,15 +2,20 @@ package topology
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
 )
 
 func Contains(a []string, x string) bool {
@@ -155,8 +160,94 @@ func TestGetRoles_ParseRolesFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestBuildRoleScheduling_Off(t *testing.T) {
+	cases := []*tarantooliov1alpha1.RoleSchedulingPolicy{
+		nil,
+		{Mode: tarantooliov1alpha1.RoleSchedulingOff},
+	}
+
+	for i, policy := range cases {
+		affinity, tolerations := BuildRoleScheduling([]string{"router", "storage"}, policy)
+		if affinity != nil {
+			t.Fatalf("%d: expected nil affinity, got %+v", i, affinity)
+		}
+		if tolerations != nil {
+			t.Fatalf("%d: expected nil tolerations, got %+v", i, tolerations)
+		}
+	}
+}
+
+func TestBuildRoleScheduling_NoRoles(t *testing.T) {
+	affinity, tolerations := BuildRoleScheduling(nil, &tarantooliov1alpha1.RoleSchedulingPolicy{Mode: tarantooliov1alpha1.RoleSchedulingRequired})
+	if affinity != nil || tolerations != nil {
+		t.Fatalf("expected nil affinity and tolerations for no roles, got %+v, %+v", affinity, tolerations)
+	}
+}
+
+func TestBuildRoleScheduling_Required(t *testing.T) {
+	affinity, tolerations := BuildRoleScheduling([]string{"router", "storage"}, &tarantooliov1alpha1.RoleSchedulingPolicy{
+		Mode: tarantooliov1alpha1.RoleSchedulingRequired,
+	})
+
+	if len(tolerations) != 2 {
+		t.Fatalf("expected 2 tolerations, got %d", len(tolerations))
+	}
+	for _, role := range []string{"router", "storage"} {
+		found := false
+		for _, toleration := range tolerations {
+			if toleration.Key == "tarantool.io/role" && toleration.Value == role && toleration.Effect == corev1.TaintEffectNoSchedule {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected a toleration for role %s, got %+v", role, tolerations)
+		}
+	}
+
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil {
+		t.Fatal("expected RequiredDuringSchedulingIgnoredDuringExecution to be set")
+	}
+	if len(required.NodeSelectorTerms) != 1 || len(required.NodeSelectorTerms[0].MatchExpressions) != 1 {
+		t.Fatalf("expected a single match expression, got %+v", required.NodeSelectorTerms)
+	}
+	expr := required.NodeSelectorTerms[0].MatchExpressions[0]
+	if expr.Key != "tarantool.io/role" || expr.Operator != corev1.NodeSelectorOpIn {
+		t.Fatalf("unexpected match expression %+v", expr)
+	}
+	if !Contains(expr.Values, "router") || !Contains(expr.Values, "storage") {
+		t.Fatalf("expected match expression values to contain both roles, got %+v", expr.Values)
+	}
+}
+
+func TestBuildRoleScheduling_PreferredUsesWeightAndTaintKey(t *testing.T) {
+	affinity, tolerations := BuildRoleScheduling([]string{"router"}, &tarantooliov1alpha1.RoleSchedulingPolicy{
+		Mode:     tarantooliov1alpha1.RoleSchedulingPreferred,
+		Weight:   42,
+		TaintKey: "example.com/tarantool-role",
+	})
+
+	if len(tolerations) != 1 || tolerations[0].Key != "example.com/tarantool-role" {
+		t.Fatalf("expected toleration keyed on custom TaintKey, got %+v", tolerations)
+	}
+
+	preferred := affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(preferred) != 1 {
+		t.Fatalf("expected a single preferred term, got %+v", preferred)
+	}
+	if preferred[0].Weight != 42 {
+		t.Fatalf("expected weight 42, got %d", preferred[0].Weight)
+	}
+	if preferred[0].Preference.MatchExpressions[0].Key != "example.com/tarantool-role" {
+		t.Fatalf("expected preference to use custom TaintKey, got %+v", preferred[0].Preference)
+	}
+}
+
 type FailoverVariables struct {
-	Mode string `json:"mode"`
+	Mode            string                `json:"mode"`
+	StateProvider   string                `json:"state_provider"`
+	Etcd2Params     *etcd2ParamsInput     `json:"etcd2_params"`
+	TarantoolParams *tarantoolParamsInput `json:"tarantool_params"`
 }
 
 type FailoverQuery struct {
@@ -164,14 +255,6 @@ type FailoverQuery struct {
 	Variables FailoverVariables `json:"variables"`
 }
 
-var setFailoverGQL = `mutation setFailoverMode($mode: String) {
-	cluster {
-		failover_params(mode: $mode) {
-		  mode
-		}
-	}
-}`
-
 func TestSetFailover(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		b, err := ioutil.ReadAll(r.Body)
@@ -183,7 +266,7 @@ func TestSetFailover(t *testing.T) {
 			t.Fatalf("Wrong qeury: %s", err)
 		}
 
-		if query.Query != setFailoverGQL {
+		if query.Query != setFailoverMutation {
 			t.Fatalf("Wrong query: %s", query.Query)
 		}
 
@@ -209,7 +292,68 @@ func TestSetFailover(t *testing.T) {
 		clusterID:   "uuid",
 	}
 
-	err := topology.SetFailover(true)
+	err := topology.SetFailover(FailoverConfig{Mode: FailoverModeEventual})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+}
+
+func TestSetFailover_StatefulRequiresProvider(t *testing.T) {
+	topology := BuiltInTopologyService{serviceHost: "http://unused", clusterID: "uuid"}
+
+	if err := topology.SetFailover(FailoverConfig{Mode: FailoverModeStateful}); err == nil {
+		t.Fatal("expected an error for stateful failover without a state provider")
+	}
+}
+
+func TestSetFailover_Stateful(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		query := FailoverQuery{}
+		if err = json.Unmarshal(b, &query); err != nil {
+			t.Fatalf("Wrong qeury: %s", err)
+		}
+
+		if query.Variables.Mode != "stateful" {
+			t.Fatalf("Wrong failover mode: %s", query.Variables.Mode)
+		}
+		if query.Variables.StateProvider != "etcd2" {
+			t.Fatalf("Wrong state provider: %s", query.Variables.StateProvider)
+		}
+		if query.Variables.Etcd2Params == nil || len(query.Variables.Etcd2Params.Endpoints) != 1 {
+			t.Fatalf("Wrong etcd2 params: %+v", query.Variables.Etcd2Params)
+		}
+
+		_, _ = io.WriteString(w, `{
+			"data": {
+			  "cluster": {
+				"failover_params": {
+				  "mode": "stateful",
+				  "state_provider": "etcd2"
+				}
+			  }
+			}
+		}`)
+	}))
+
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{
+		serviceHost: srv.URL,
+		clusterID:   "uuid",
+	}
+
+	err := topology.SetFailover(FailoverConfig{
+		Mode:          FailoverModeStateful,
+		StateProvider: FailoverStateProviderETCD2,
+		ETCD2: &ETCD2FailoverParams{
+			Endpoints: []string{"http://etcd:2379"},
+			Prefix:    "/tarantool",
+		},
+	})
 	if err != nil {
 		t.Fatalf("%s", err)
 	}
@@ -219,6 +363,7 @@ var getFailoverGQL = `query {
 	cluster {
 		failover_params {
 			mode
+			state_provider
 		}
 	}
 }`
@@ -318,3 +463,388 @@ func TestGetFailover(t *testing.T) {
 		t.Fatal("Wrong answer format, but error wasn't thrown")
 	}
 }
+
+// TestSessionRoundTripper_LoginsOnce verifies that a BuiltInTopologyService configured
+// with WithBasicAuth logs into Cartridge's /login endpoint exactly once, reusing the
+// cached lsid cookie on subsequent requests instead of re-authenticating every call.
+func TestSessionRoundTripper_LoginsOnce(t *testing.T) {
+	var logins int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			logins++
+			b, _ := ioutil.ReadAll(r.Body)
+			form := string(b)
+			if !strings.Contains(form, "username=admin") || !strings.Contains(form, "password=secret") {
+				t.Fatalf("wrong login form: %s", form)
+			}
+			http.SetCookie(w, &http.Cookie{Name: "lsid", Value: "session-token"})
+			return
+		}
+
+		cookie, err := r.Cookie("lsid")
+		if err != nil || cookie.Value != "session-token" {
+			t.Fatalf("request missing lsid cookie: %v", err)
+		}
+
+		_, _ = io.WriteString(w, `{"data": {"snapshot": true}}`)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{
+		serviceHost: srv.URL,
+		clusterID:   "uuid",
+		username:    "admin",
+		password:    "secret",
+	}
+
+	if err := topology.Snapshot(); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := topology.Snapshot(); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if logins != 1 {
+		t.Fatalf("expected exactly 1 login, got %d", logins)
+	}
+}
+
+// TestSessionRoundTripper_RelogsOn401 verifies that a request answered with 401 (an
+// expired or forgotten session) triggers exactly one re-login and retry.
+func TestSessionRoundTripper_RelogsOn401(t *testing.T) {
+	var logins int
+	validCookie := "token-1"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			logins++
+			validCookie = fmt.Sprintf("token-%d", logins)
+			http.SetCookie(w, &http.Cookie{Name: "lsid", Value: validCookie})
+			return
+		}
+
+		cookie, err := r.Cookie("lsid")
+		if err != nil || cookie.Value != validCookie || logins < 2 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"data": {"snapshot": true}}`)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{
+		serviceHost: srv.URL,
+		clusterID:   "uuid",
+		username:    "admin",
+		password:    "secret",
+	}
+
+	if err := topology.Snapshot(); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if logins != 2 {
+		t.Fatalf("expected a re-login after the 401, got %d logins", logins)
+	}
+}
+
+// TestWithTimeout verifies the option overrides the default 5s client timeout.
+func TestWithTimeout(t *testing.T) {
+	topology := NewBuiltInTopologyService(WithTimeout(42 * time.Second))
+	if topology.httpClient().Timeout != 42*time.Second {
+		t.Fatalf("expected 42s timeout, got %s", topology.httpClient().Timeout)
+	}
+}
+
+// TestWithCACertFromSecret_InvalidPEM verifies the option rejects a ca.crt that doesn't
+// contain a parseable certificate, instead of silently disabling CA verification.
+func TestWithCACertFromSecret_InvalidPEM(t *testing.T) {
+	if _, err := WithCACertFromSecret([]byte("not a cert")); err == nil {
+		t.Fatal("expected an error for invalid PEM data")
+	}
+}
+
+// gqlErrorResponse builds a Cartridge-style GraphQL error envelope carrying a single
+// errors[] entry with the given class name and message.
+func gqlErrorResponse(className, message string) string {
+	env := map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{
+				"message": message,
+				"extensions": map[string]interface{}{
+					"class_name": className,
+				},
+			},
+		},
+	}
+	b, _ := json.Marshal(env)
+	return string(b)
+}
+
+func TestDoGraphQL_StructuredError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, gqlErrorResponse("AlreadyJoined", "already joined"))
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{serviceHost: srv.URL}
+
+	err := topology.doGraphQL("query {}", nil, nil)
+	var topoErr *TopologyError
+	if !errors.As(err, &topoErr) {
+		t.Fatalf("expected a *TopologyError, got %T: %s", err, err)
+	}
+	if topoErr.ClassName != "AlreadyJoined" || topoErr.Message != "already joined" {
+		t.Fatalf("unexpected TopologyError: %+v", topoErr)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"nil", nil, false},
+		{"topology down", errTopologyIsDown, true},
+		{"patch in progress", &TopologyError{ClassName: "PatchInProgress", Message: "patch in progress"}, true},
+		{"already joined", errAlreadyJoined, false},
+		{"already bootstrapped", errAlreadyBootstrapped, false},
+		{"bucket rebalancing", &TopologyError{ClassName: "BucketsRebalancing"}, true},
+	}
+
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.retryable {
+			t.Errorf("%s: IsRetryable() = %v, want %v", c.name, got, c.retryable)
+		}
+	}
+}
+
+func TestWithRetry_RetriesTransientThenSucceeds(t *testing.T) {
+	topology := &BuiltInTopologyService{retryPolicy: RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}}
+
+	attempts := 0
+	err := topology.withRetry(func() error {
+		attempts++
+		if attempts < 3 {
+			return errTopologyIsDown
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetry_StopsOnTerminalError(t *testing.T) {
+	topology := &BuiltInTopologyService{retryPolicy: RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}}
+
+	attempts := 0
+	err := topology.withRetry(func() error {
+		attempts++
+		return errAlreadyJoined
+	})
+	if err != errAlreadyJoined {
+		t.Fatalf("expected errAlreadyJoined, got %s", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt for a terminal error, got %d", attempts)
+	}
+}
+
+func TestWithRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	topology := &BuiltInTopologyService{retryPolicy: RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}}
+
+	attempts := 0
+	err := topology.withRetry(func() error {
+		attempts++
+		return errTopologyIsDown
+	})
+	if err != errTopologyIsDown {
+		t.Fatalf("expected errTopologyIsDown, got %s", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 1 initial attempt + 2 retries = 3, got %d", attempts)
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{
+			"data": {
+				"cluster": {
+					"config_sections": [
+						{"filename": "vshard_groups.yml", "content": "default: {}"}
+					]
+				}
+			}
+		}`)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{serviceHost: srv.URL}
+
+	config, err := topology.GetConfig([]string{"vshard_groups.yml"})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if string(config["vshard_groups.yml"]) != "default: {}" {
+		t.Fatalf("unexpected config: %+v", config)
+	}
+}
+
+func TestApplyConfig_Inline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if !strings.Contains(string(b), "vshard_groups.yml") {
+			t.Fatalf("expected request to mention the section filename, got %s", b)
+		}
+
+		_, _ = io.WriteString(w, `{
+			"data": {
+				"cluster": {
+					"edit_config": [
+						{"filename": "vshard_groups.yml", "content": "default: {}"}
+					]
+				}
+			}
+		}`)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{serviceHost: srv.URL}
+
+	err := topology.ApplyConfig(map[string][]byte{"vshard_groups.yml": []byte("default: {}")})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+}
+
+func TestApplyConfig_LargeSectionUsesMultipartUpload(t *testing.T) {
+	var uploadedPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin/config" {
+			uploadedPath = r.URL.Path
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("%s", err)
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		t.Fatalf("unexpected request to %s, large sections should skip GraphQL entirely", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{serviceHost: srv.URL + "/admin/api"}
+
+	large := make([]byte, maxInlineConfigSectionSize+1)
+	err := topology.ApplyConfig(map[string][]byte{"big-section.lua": large})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if uploadedPath != "/admin/config" {
+		t.Fatalf("expected the large section to be uploaded to /admin/config, got %q", uploadedPath)
+	}
+}
+
+func TestEditReplicaset_OnlySendsSetFields(t *testing.T) {
+	var body struct {
+		Variables map[string]interface{} `json:"variables"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		_, _ = io.WriteString(w, `{"data": {"editReplicasetResponse": true}}`)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{serviceHost: srv.URL}
+
+	weight := 200.0
+	err := topology.EditReplicaset("uuid", EditReplicasetOptions{Weight: &weight, VshardGroup: "hot"})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if body.Variables["weight"] != 200.0 {
+		t.Fatalf("expected weight 200, got %v", body.Variables["weight"])
+	}
+	if body.Variables["vshard_group"] != "hot" {
+		t.Fatalf("expected vshard_group %q, got %v", "hot", body.Variables["vshard_group"])
+	}
+	if _, ok := body.Variables["all_rw"]; ok {
+		t.Fatalf("expected all_rw to be omitted when unset, got %v", body.Variables["all_rw"])
+	}
+	if _, ok := body.Variables["alias"]; ok {
+		t.Fatalf("expected alias to be omitted when unset, got %v", body.Variables["alias"])
+	}
+}
+
+func TestBootstrapVshardGroup(t *testing.T) {
+	var requestedGroup string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables struct {
+				Name string `json:"name"`
+			} `json:"variables"`
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Fatalf("%s", err)
+		}
+		requestedGroup = body.Variables.Name
+
+		_, _ = io.WriteString(w, `{"data": {"bootstrapVshardResponse": true}}`)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{serviceHost: srv.URL}
+
+	if err := topology.BootstrapVshardGroup("hot"); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if requestedGroup != "hot" {
+		t.Fatalf("expected group %q, got %q", "hot", requestedGroup)
+	}
+}
+
+func TestGetVshardGroups(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{
+			"data": {
+				"vshardGroups": [
+					{"name": "hot", "bucket_count": 3000},
+					{"name": "cold", "bucket_count": 1000}
+				]
+			}
+		}`)
+	}))
+	defer srv.Close()
+
+	topology := BuiltInTopologyService{serviceHost: srv.URL}
+
+	groups, err := topology.GetVshardGroups()
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "hot" || groups[1].Name != "cold" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
@@ -0,0 +1,100 @@
+package topology
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForwardTransport is an http.RoundTripper that reaches a single pod's port by
+// tunneling through the Kubernetes API server's pods/portforward subresource, the
+// same SPDY mechanism `kubectl port-forward` uses, instead of dialing the pod's IP
+// directly. This lets BuiltInTopologyService run against a pod that the operator
+// process can't route to directly -- out-of-cluster, or under envtest, where pod IPs
+// aren't routable at all.
+//
+// The port-forward session is established once, in NewPortForwardTransport, and kept
+// open for the life of the PortForwardTransport; RoundTrip only ever dials the
+// resulting local listener.
+type PortForwardTransport struct {
+	fw        *portforward.PortForwarder
+	stopCh    chan struct{}
+	localAddr string
+}
+
+// NewPortForwardTransport opens a port-forward session to pod's port podPort and
+// returns a PortForwardTransport that tunnels every request through it. The caller
+// must call Close when the transport is no longer needed, to stop the underlying
+// port-forward session.
+func NewPortForwardTransport(restConfig *rest.Config, pod types.NamespacedName, podPort int) (*PortForwardTransport, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building clientset for port-forward: %w", err)
+	}
+
+	reqURL := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+
+	roundTripper, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building SPDY round tripper for port-forward: %w", err)
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, reqURL)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", podPort)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		close(stopCh)
+		return nil, fmt.Errorf("setting up port-forward to pod %s: %w", pod, err)
+	}
+
+	forwardErrCh := make(chan error, 1)
+	go func() { forwardErrCh <- fw.ForwardPorts() }()
+
+	select {
+	case <-readyCh:
+	case err := <-forwardErrCh:
+		close(stopCh)
+		return nil, fmt.Errorf("port-forward to pod %s exited before becoming ready: %w", pod, err)
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		close(stopCh)
+		return nil, fmt.Errorf("reading forwarded port for pod %s: %w", pod, err)
+	}
+
+	return &PortForwardTransport{
+		fw:        fw,
+		stopCh:    stopCh,
+		localAddr: fmt.Sprintf("127.0.0.1:%d", ports[0].Local),
+	}, nil
+}
+
+// RoundTrip rewrites req to target the local end of the port-forward tunnel and sends
+// it with the default transport, regardless of what host/scheme req.URL originally named.
+func (t *PortForwardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = "http"
+	req.URL.Host = t.localAddr
+	req.Host = t.localAddr
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// Close stops the underlying port-forward session. Further requests through this
+// transport will fail.
+func (t *PortForwardTransport) Close() {
+	close(t.stopCh)
+}
@@ -0,0 +1,210 @@
+package topology
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// byRoleIndex is the RoleIndex indexer name for per-role Pod lookups.
+const byRoleIndex = "byRole"
+
+// byReplicasetIndex is the RoleIndex indexer name for per-replicaset Pod lookups.
+const byReplicasetIndex = "byReplicaset"
+
+// byRoleStateIndex is the RoleIndex indexer name for per-role, per-instance-state Pod
+// lookups, keyed "<role>/<state>".
+const byRoleStateIndex = "byRoleState"
+
+// byClusterStateIndex is the RoleIndex indexer name for per-cluster, per-instance-state
+// Pod lookups, keyed "<clusterID>/<state>".
+const byClusterStateIndex = "byClusterState"
+
+// These mirror the tarantool.io/instance-state label values controllers/tarantool's
+// MarkJoined/MarkExpelling stamp onto a Pod; duplicated here as literals rather than
+// imported constants since every other label value in this file (e.g.
+// tarantool.io/replicaset-uuid) is already handled the same way.
+const (
+	stateJoined    = "joined"
+	stateExpelling = "expelling"
+)
+
+// RoleIndex maintains byRole, byReplicaset and per-instance-state indexes over a
+// cluster's Pods on top of a cache.SharedIndexInformer, so the repeated role/replicaset
+// List calls reconciling and leader-electing a Cluster used to make are O(1) cache
+// reads instead.
+type RoleIndex struct {
+	informer cache.SharedIndexInformer
+}
+
+// NewRoleIndex registers RoleIndex's indexers on informer and returns a RoleIndex
+// backed by it. informer must be started (and its cache synced) by the caller before
+// any lookup method returns anything useful.
+func NewRoleIndex(informer cache.SharedIndexInformer) (*RoleIndex, error) {
+	if err := informer.AddIndexers(cache.Indexers{
+		byRoleIndex:         indexPodsByRole,
+		byReplicasetIndex:   indexPodsByReplicaset,
+		byRoleStateIndex:    indexPodsByRoleState,
+		byClusterStateIndex: indexPodsByClusterState,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &RoleIndex{informer: informer}, nil
+}
+
+// indexPodsByRole is a cache.IndexFunc keying a Pod under every role GetRoles parses
+// off it, so a Pod with rolesToAssign "router.storage" appears under both "router"
+// and "storage". Pods with no parseable roles are simply left out of the index.
+func indexPodsByRole(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	roles, err := GetRoles(pod)
+	if err != nil {
+		return nil, nil
+	}
+
+	return roles, nil
+}
+
+// indexPodsByReplicaset is a cache.IndexFunc keying a Pod under its
+// tarantool.io/replicaset-uuid label.
+func indexPodsByReplicaset(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	replicasetUUID, ok := pod.GetLabels()["tarantool.io/replicaset-uuid"]
+	if !ok {
+		return nil, nil
+	}
+
+	return []string{replicasetUUID}, nil
+}
+
+// indexPodsByRoleState is a cache.IndexFunc keying a Pod under "<role>/<state>" for
+// every role GetRoles parses off it, so JoinedPodsInRole doesn't have to re-derive
+// roles or re-check instance-state on every call.
+func indexPodsByRoleState(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	state, ok := pod.GetLabels()["tarantool.io/instance-state"]
+	if !ok {
+		return nil, nil
+	}
+
+	roles, err := GetRoles(pod)
+	if err != nil || len(roles) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(roles))
+	for i, role := range roles {
+		keys[i] = role + "/" + state
+	}
+
+	return keys, nil
+}
+
+// indexPodsByClusterState is a cache.IndexFunc keying a Pod under
+// "<clusterID>/<state>", read off its tarantool.io/cluster-id and
+// tarantool.io/instance-state labels.
+func indexPodsByClusterState(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	clusterID, ok := pod.GetLabels()["tarantool.io/cluster-id"]
+	if !ok {
+		return nil, nil
+	}
+
+	state, ok := pod.GetLabels()["tarantool.io/instance-state"]
+	if !ok {
+		return nil, nil
+	}
+
+	return []string{clusterID + "/" + state}, nil
+}
+
+// PodsForRole returns every currently indexed Pod with role in its rolesToAssign.
+func (idx *RoleIndex) PodsForRole(role string) []*corev1.Pod {
+	objs, err := idx.informer.GetIndexer().ByIndex(byRoleIndex, role)
+	if err != nil {
+		return nil
+	}
+
+	return toPods(objs)
+}
+
+// PodsForReplicaset returns every currently indexed Pod belonging to the replicaset
+// identified by replicasetUUID.
+func (idx *RoleIndex) PodsForReplicaset(replicasetUUID string) []*corev1.Pod {
+	objs, err := idx.informer.GetIndexer().ByIndex(byReplicasetIndex, replicasetUUID)
+	if err != nil {
+		return nil
+	}
+
+	return toPods(objs)
+}
+
+// JoinedPodsInRole returns every currently indexed Pod with role in its rolesToAssign
+// that has already joined the cluster.
+func (idx *RoleIndex) JoinedPodsInRole(role string) []*corev1.Pod {
+	objs, err := idx.informer.GetIndexer().ByIndex(byRoleStateIndex, role+"/"+stateJoined)
+	if err != nil {
+		return nil
+	}
+
+	return toPods(objs)
+}
+
+// ExpellingPods returns every currently indexed Pod labeled with clusterID that is
+// mid-expel.
+func (idx *RoleIndex) ExpellingPods(clusterID string) []*corev1.Pod {
+	objs, err := idx.informer.GetIndexer().ByIndex(byClusterStateIndex, clusterID+"/"+stateExpelling)
+	if err != nil {
+		return nil
+	}
+
+	return toPods(objs)
+}
+
+// LeaderCandidates returns every indexed Pod labeled with clusterID, i.e. every Pod
+// eligible to stand for that Cluster's leader election.
+func (idx *RoleIndex) LeaderCandidates(clusterID string) []*corev1.Pod {
+	objs := idx.informer.GetStore().List()
+
+	candidates := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		if pod.GetLabels()["tarantool.io/cluster-id"] == clusterID {
+			candidates = append(candidates, pod)
+		}
+	}
+
+	return candidates
+}
+
+// toPods filters objs down to the *corev1.Pod values a SharedIndexInformer's indexer
+// returns, discarding anything that isn't one.
+func toPods(objs []interface{}) []*corev1.Pod {
+	pods := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods
+}
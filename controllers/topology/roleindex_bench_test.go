@@ -0,0 +1,74 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// benchPods builds n Pods split evenly between the "router" and "storage" roles, all
+// in cluster c1, with every third Pod marked joined, for BenchmarkJoinedPodsInRole's
+// indexed-vs-scan comparison.
+func benchPods(n int) []*corev1.Pod {
+	pods := make([]*corev1.Pod, 0, n)
+	for i := 0; i < n; i++ {
+		role := "storage"
+		if i%2 == 0 {
+			role = "router"
+		}
+		state := ""
+		if i%3 == 0 {
+			state = "joined"
+		}
+		pods = append(pods, newIndexedPodWithState(
+			fmt.Sprintf("%s-%d", role, i), "c1", fmt.Sprintf("rs-%d", i), role, state,
+		))
+	}
+
+	return pods
+}
+
+// scanJoinedPodsInRole re-derives what JoinedPodsInRole returns by walking every Pod
+// and checking its role/state directly, the way a fresh List+selector call (or a
+// manual filter over one) would have to before this index existed.
+func scanJoinedPodsInRole(pods []*corev1.Pod, role string) []*corev1.Pod {
+	var matches []*corev1.Pod
+	for _, pod := range pods {
+		if pod.GetLabels()["tarantool.io/instance-state"] != stateJoined {
+			continue
+		}
+
+		roles, err := GetRoles(pod)
+		if err != nil {
+			continue
+		}
+		for _, r := range roles {
+			if r == role {
+				matches = append(matches, pod)
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+func BenchmarkJoinedPodsInRole_Indexed(b *testing.B) {
+	pods := benchPods(1000)
+	idx := newTestRoleIndex(b, pods...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.JoinedPodsInRole("router")
+	}
+}
+
+func BenchmarkJoinedPodsInRole_Scan(b *testing.B) {
+	pods := benchPods(1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scanJoinedPodsInRole(pods, "router")
+	}
+}
@@ -0,0 +1,176 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// nopListerWatcher never lists or watches anything; tests populate the informer's
+// store directly and never call Run, so it's only here to satisfy the constructor.
+type nopListerWatcher struct{}
+
+func (nopListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	return &corev1.PodList{}, nil
+}
+
+func (nopListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	return watch.NewEmptyWatch(), nil
+}
+
+func newTestRoleIndex(t testing.TB, pods ...*corev1.Pod) *RoleIndex {
+	t.Helper()
+
+	informer := cache.NewSharedIndexInformer(nopListerWatcher{}, &corev1.Pod{}, 0, cache.Indexers{})
+	idx, err := NewRoleIndex(informer)
+	if err != nil {
+		t.Fatalf("unexpected error building RoleIndex: %s", err)
+	}
+
+	for _, pod := range pods {
+		if err := informer.GetStore().Add(pod); err != nil {
+			t.Fatalf("unexpected error seeding store: %s", err)
+		}
+	}
+
+	return idx
+}
+
+func newIndexedPod(name, clusterID, replicasetUUID, rolesToAssign, podIP string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"tarantool.io/cluster-id":      clusterID,
+				"tarantool.io/replicaset-uuid": replicasetUUID,
+				"tarantool.io/rolesToAssign":   rolesToAssign,
+			},
+		},
+		Status: corev1.PodStatus{PodIP: podIP},
+	}
+}
+
+func newIndexedPodWithState(name, clusterID, replicasetUUID, rolesToAssign, state string) *corev1.Pod {
+	pod := newIndexedPod(name, clusterID, replicasetUUID, rolesToAssign, "10.0.0.1")
+	pod.Labels["tarantool.io/instance-state"] = state
+	return pod
+}
+
+func TestRoleIndex_PodsForRole_MultiRoleFanOut(t *testing.T) {
+	router := newIndexedPod("router-0", "c1", "rs-1", "router", "10.0.0.1")
+	storage := newIndexedPod("storage-0", "c1", "rs-2", "storage", "10.0.0.2")
+	both := newIndexedPod("both-0", "c1", "rs-3", "router.storage", "10.0.0.3")
+
+	idx := newTestRoleIndex(t, router, storage, both)
+
+	routerPods := idx.PodsForRole("router")
+	if len(routerPods) != 2 {
+		t.Fatalf("expected 2 pods for role router, got %d", len(routerPods))
+	}
+
+	storagePods := idx.PodsForRole("storage")
+	if len(storagePods) != 2 {
+		t.Fatalf("expected 2 pods for role storage, got %d", len(storagePods))
+	}
+
+	unknownPods := idx.PodsForRole("unknown")
+	if len(unknownPods) != 0 {
+		t.Fatalf("expected 0 pods for role unknown, got %d", len(unknownPods))
+	}
+}
+
+func TestRoleIndex_PodsForReplicaset(t *testing.T) {
+	a := newIndexedPod("a-0", "c1", "rs-1", "router", "10.0.0.1")
+	b := newIndexedPod("b-0", "c1", "rs-1", "router", "10.0.0.2")
+	c := newIndexedPod("c-0", "c1", "rs-2", "storage", "10.0.0.3")
+
+	idx := newTestRoleIndex(t, a, b, c)
+
+	pods := idx.PodsForReplicaset("rs-1")
+	if len(pods) != 2 {
+		t.Fatalf("expected 2 pods for replicaset rs-1, got %d", len(pods))
+	}
+}
+
+func TestRoleIndex_LeaderCandidates(t *testing.T) {
+	c1Pod := newIndexedPod("c1-0", "c1", "rs-1", "router", "10.0.0.1")
+	c2Pod := newIndexedPod("c2-0", "c2", "rs-1", "router", "10.0.0.2")
+
+	idx := newTestRoleIndex(t, c1Pod, c2Pod)
+
+	candidates := idx.LeaderCandidates("c1")
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 candidate for cluster c1, got %d", len(candidates))
+	}
+	if candidates[0].GetName() != "c1-0" {
+		t.Fatalf("expected candidate c1-0, got %s", candidates[0].GetName())
+	}
+}
+
+func TestRoleIndex_JoinedPodsInRole(t *testing.T) {
+	joinedRouter := newIndexedPodWithState("router-0", "c1", "rs-1", "router", "joined")
+	pendingRouter := newIndexedPodWithState("router-1", "c1", "rs-2", "router", "")
+	joinedStorage := newIndexedPodWithState("storage-0", "c1", "rs-3", "storage", "joined")
+
+	idx := newTestRoleIndex(t, joinedRouter, pendingRouter, joinedStorage)
+
+	pods := idx.JoinedPodsInRole("router")
+	if len(pods) != 1 {
+		t.Fatalf("expected 1 joined pod for role router, got %d", len(pods))
+	}
+	if pods[0].GetName() != "router-0" {
+		t.Fatalf("expected joined pod router-0, got %s", pods[0].GetName())
+	}
+}
+
+func TestRoleIndex_ExpellingPods(t *testing.T) {
+	expelling := newIndexedPodWithState("storage-0", "c1", "rs-1", "storage", "expelling")
+	joined := newIndexedPodWithState("storage-1", "c1", "rs-2", "storage", "joined")
+	otherCluster := newIndexedPodWithState("other-storage-0", "c2", "rs-1", "storage", "expelling")
+
+	idx := newTestRoleIndex(t, expelling, joined, otherCluster)
+
+	pods := idx.ExpellingPods("c1")
+	if len(pods) != 1 {
+		t.Fatalf("expected 1 expelling pod for cluster c1, got %d", len(pods))
+	}
+	if pods[0].GetName() != "storage-0" {
+		t.Fatalf("expected expelling pod storage-0, got %s", pods[0].GetName())
+	}
+}
+
+func TestRoleIndex_LookupIsConstantTime(t *testing.T) {
+	pods := make([]*corev1.Pod, 0, 500)
+	for i := 0; i < 500; i++ {
+		role := "storage"
+		if i%2 == 0 {
+			role = "router"
+		}
+		pods = append(pods, newIndexedPod(
+			fmt.Sprintf("%s-%d", role, i),
+			"c1",
+			fmt.Sprintf("rs-%d", i),
+			role,
+			"10.0.0.1",
+		))
+	}
+	idx := newTestRoleIndex(t, pods...)
+
+	start := time.Now()
+	routerPods := idx.PodsForRole("router")
+	elapsed := time.Since(start)
+
+	if len(routerPods) != 250 {
+		t.Fatalf("expected 250 router pods, got %d", len(routerPods))
+	}
+	if elapsed > time.Second {
+		t.Fatalf("expected an indexed lookup to be fast, took %s", elapsed)
+	}
+}
@@ -4,8 +4,26 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-// TopologyService .
+// TopologyService is everything ClusterReconciler needs from a Cartridge cluster's
+// admin/topology API. It mirrors *BuiltInTopologyService's method set in full (rather
+// than just Join/Expel, as originally) so tests can supply a fake implementation
+// instead of standing up a real Cartridge cluster.
 type TopologyService interface {
 	Join(p *corev1.Pod) error
 	Expel(p *corev1.Pod) error
+	EditReplicaset(replicasetUUID string, opts EditReplicasetOptions) error
+	SetReplicasetRoles(replicasetUUID string, roles []string) error
+	GetReplicasetRolesFromService(replicasetUUID string) ([]string, error)
+	GetServerStat() (ServerStatData, error)
+	BootstrapVshard() error
+	BootstrapVshardGroup(name string) error
+	GetVshardGroups() ([]*VshardGroup, error)
+	SetFailover(cfg FailoverConfig) error
+	GetFailoverStatus() (*FailoverStatus, error)
+	GetFailover() (bool, error)
+	Snapshot() error
+	GetConfig(sections []string) (map[string][]byte, error)
+	ApplyConfig(sections map[string][]byte) error
 }
+
+var _ TopologyService = (*BuiltInTopologyService)(nil)
@@ -1,5 +1,26 @@
 package utils
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// HashPodTemplate returns a stable hex digest of a pod template, so callers can tell
+// whether a StatefulSet's desired pod spec has moved on from what a given Pod was
+// created with without having to deep-compare the structs field by field.
+func HashPodTemplate(tmpl corev1.PodTemplateSpec) (string, error) {
+	b, err := json.Marshal(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func IsRolesEquals(rolesA, rolesB []string) bool {
 	isSubset := func(X, Y []string) bool {
 		for _, x := range X {
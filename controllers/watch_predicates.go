@@ -0,0 +1,107 @@
+/*
+BSD 2-Clause License
+
+Copyright (c) 2019, Tarantool
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// clusterIDLabel is the label ManagedPodPredicate requires on a Pod before it's
+// considered part of any Cluster this operator instance manages.
+const clusterIDLabel = "tarantool.io/cluster-id"
+
+// ManagedPodPredicate builds the predicate.Predicate ClusterReconciler's Pod watch is
+// filtered through: a Pod only triggers a reconcile if it carries clusterIDLabel, sits
+// in namespace (every namespace, if empty), and matches selector (every Pod, if nil).
+// The three checks are composed with predicate.And rather than folded into one
+// function, so each axis -- the operator-wide namespace scope and label-selector scope,
+// plus the baseline "is this even a tarantool Pod" check -- can be read and tested on
+// its own. This lets multiple operator instances (e.g. one per tenant, or sharded by
+// cluster-label-selector) watch the same Kubernetes cluster without reconciling each
+// other's Pods.
+func ManagedPodPredicate(namespace string, selector labels.Selector) predicate.Predicate {
+	return predicate.And(
+		hasClusterIDLabel(),
+		inNamespace(namespace),
+		matchesSelector(selector),
+	)
+}
+
+// objectPredicate builds a predicate.Funcs applying filter to e.Object for
+// Create/Delete/Generic events, and to *either* e.ObjectOld or e.ObjectNew for an
+// Update event -- unlike predicate.NewPredicateFuncs, which only checks e.ObjectNew and
+// so would miss a Pod update that makes it stop matching filter (e.g. a label that's
+// removed), as well as one that makes it start matching (e.g. a label added after
+// creation).
+func objectPredicate(filter func(client.Object) bool) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return filter(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return filter(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return filter(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return filter(e.ObjectOld) || filter(e.ObjectNew)
+		},
+	}
+}
+
+// hasClusterIDLabel matches any object carrying a non-empty clusterIDLabel.
+func hasClusterIDLabel() predicate.Funcs {
+	return objectPredicate(func(o client.Object) bool {
+		return o.GetLabels()[clusterIDLabel] != ""
+	})
+}
+
+// inNamespace matches every object if namespace is empty (the operator watching every
+// namespace, its default), restricting the watch to a single namespace otherwise -- the
+// --watch-namespace operator flag's handle.
+func inNamespace(namespace string) predicate.Funcs {
+	if namespace == "" {
+		return predicate.Funcs{}
+	}
+
+	return objectPredicate(func(o client.Object) bool {
+		return o.GetNamespace() == namespace
+	})
+}
+
+// matchesSelector matches every object if selector is nil (the operator managing every
+// Cluster it can see, its default), restricting the watch to objects matching selector
+// otherwise -- the --cluster-label-selector operator flag's handle.
+func matchesSelector(selector labels.Selector) predicate.Funcs {
+	if selector == nil {
+		return predicate.Funcs{}
+	}
+
+	return objectPredicate(func(o client.Object) bool {
+		return selector.Matches(labels.Set(o.GetLabels()))
+	})
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func podWithLabels(namespace string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Labels: labels}}
+}
+
+func TestManagedPodPredicate_RequiresClusterIDLabel(t *testing.T) {
+	pred := ManagedPodPredicate("", nil)
+
+	withLabel := podWithLabels("default", map[string]string{clusterIDLabel: "c1"})
+	withoutLabel := podWithLabels("default", map[string]string{})
+
+	if !pred.Create(event.CreateEvent{Object: withLabel}) {
+		t.Fatalf("expected a pod with %s to be managed", clusterIDLabel)
+	}
+	if pred.Create(event.CreateEvent{Object: withoutLabel}) {
+		t.Fatalf("expected a pod without %s to not be managed", clusterIDLabel)
+	}
+}
+
+func TestManagedPodPredicate_NamespaceScope(t *testing.T) {
+	pred := ManagedPodPredicate("team-a", nil)
+
+	inScope := podWithLabels("team-a", map[string]string{clusterIDLabel: "c1"})
+	outOfScope := podWithLabels("team-b", map[string]string{clusterIDLabel: "c1"})
+
+	if !pred.Create(event.CreateEvent{Object: inScope}) {
+		t.Fatalf("expected a pod in the watched namespace to be managed")
+	}
+	if pred.Create(event.CreateEvent{Object: outOfScope}) {
+		t.Fatalf("expected a pod outside the watched namespace to not be managed")
+	}
+}
+
+func TestManagedPodPredicate_LabelSelectorScope(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set{"shard": "a"})
+	pred := ManagedPodPredicate("", selector)
+
+	matching := podWithLabels("default", map[string]string{clusterIDLabel: "c1", "shard": "a"})
+	nonMatching := podWithLabels("default", map[string]string{clusterIDLabel: "c1", "shard": "b"})
+
+	if !pred.Create(event.CreateEvent{Object: matching}) {
+		t.Fatalf("expected a pod matching the selector to be managed")
+	}
+	if pred.Create(event.CreateEvent{Object: nonMatching}) {
+		t.Fatalf("expected a pod not matching the selector to not be managed")
+	}
+}
+
+func TestManagedPodPredicate_UpdateChecksOldAndNew(t *testing.T) {
+	pred := ManagedPodPredicate("", nil)
+
+	gainedLabel := event.UpdateEvent{
+		ObjectOld: podWithLabels("default", map[string]string{}),
+		ObjectNew: podWithLabels("default", map[string]string{clusterIDLabel: "c1"}),
+	}
+	if !pred.Update(gainedLabel) {
+		t.Fatalf("expected an update that gains %s to be managed", clusterIDLabel)
+	}
+
+	lostLabel := event.UpdateEvent{
+		ObjectOld: podWithLabels("default", map[string]string{clusterIDLabel: "c1"}),
+		ObjectNew: podWithLabels("default", map[string]string{}),
+	}
+	if !pred.Update(lostLabel) {
+		t.Fatalf("expected an update that loses %s to still be managed, so cleanup runs", clusterIDLabel)
+	}
+
+	neverLabeled := event.UpdateEvent{
+		ObjectOld: podWithLabels("default", map[string]string{}),
+		ObjectNew: podWithLabels("default", map[string]string{}),
+	}
+	if pred.Update(neverLabeled) {
+		t.Fatalf("expected an update with %s on neither old nor new to not be managed", clusterIDLabel)
+	}
+}
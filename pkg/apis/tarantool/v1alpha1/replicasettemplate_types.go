@@ -5,23 +5,68 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// ReplicasetOverride patches the base StatefulSetSpec for a subset of shards.
+// The role controller matches overrides by Ordinal only; Selector is not
+// evaluated, so an override without an Ordinal is never applied.
+// +k8s:openapi-gen=true
+type ReplicasetOverride struct {
+	// Ordinal selects a single shard by its StatefulSet ordinal (0-based).
+	Ordinal *int32 `json:"ordinal,omitempty"`
+	// Selector matches shards by the labels applied to their owning Role. The
+	// role controller does not evaluate it.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Patch is merged over the base StatefulSetSpec for matching shards.
+	Patch *appsv1.StatefulSetSpec `json:"patch,omitempty"`
+}
+
+// RollingUpdatePolicy describes how child StatefulSets are updated when the
+// template changes. The role controller does not read it: child StatefulSets
+// keep the update strategy set in Base.
+// +k8s:openapi-gen=true
+type RollingUpdatePolicy struct {
+	// Type is the StatefulSetUpdateStrategyType to apply to child StatefulSets.
+	Type appsv1.StatefulSetUpdateStrategyType `json:"type,omitempty"`
+	// Partition carries over to StatefulSetUpdateStrategy.RollingUpdate.Partition.
+	Partition *int32 `json:"partition,omitempty"`
+}
+
 // ReplicasetTemplateSpec defines the desired state of ReplicasetTemplate
 // +k8s:openapi-gen=true
 type ReplicasetTemplateSpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
-	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
-	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
+	// Base is the StatefulSetSpec shared by every shard before overrides are applied.
+	Base *appsv1.StatefulSetSpec `json:"base,omitempty"`
+	// Overrides patches Base for specific shards, keyed by ordinal (Selector is not
+	// evaluated).
+	Overrides []ReplicasetOverride `json:"overrides,omitempty"`
+	// RollingUpdatePolicy describes how child StatefulSets are rolled when the
+	// template changes; the role controller does not apply it.
+	RollingUpdatePolicy *RollingUpdatePolicy `json:"rollingUpdatePolicy,omitempty"`
+	// TemplateVersion identifies this revision of the template and is stamped onto
+	// child StatefulSets via the tarantool.io/template-version annotation.
+	TemplateVersion string `json:"templateVersion,omitempty"`
+}
+
+// ShardTemplateStatus reports the template version and spec hash last applied to a single shard.
+// +k8s:openapi-gen=true
+type ShardTemplateStatus struct {
+	// Name is the StatefulSet name of the shard.
+	Name string `json:"name"`
+	// TemplateVersion is the TemplateVersion applied to this shard.
+	TemplateVersion string `json:"templateVersion,omitempty"`
+	// SpecHash is the hash of the effective spec (base+override) last applied to this shard.
+	SpecHash string `json:"specHash,omitempty"`
 }
 
 // ReplicasetTemplateStatus defines the observed state of ReplicasetTemplate
 // +k8s:openapi-gen=true
 type ReplicasetTemplateStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
-	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
+	// ObservedGeneration is the most recent template generation acted on. The role
+	// controller does not write any of this status.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Shards reports the template version and spec hash applied to each shard.
+	Shards []ShardTemplateStatus `json:"shards,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -33,7 +78,7 @@ type ReplicasetTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   *appsv1.StatefulSetSpec  `json:"spec,omitempty"`
+	Spec   ReplicasetTemplateSpec   `json:"spec,omitempty"`
 	Status ReplicasetTemplateStatus `json:"status,omitempty"`
 }
 
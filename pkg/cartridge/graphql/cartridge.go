@@ -0,0 +1,278 @@
+package graphql
+
+// This file adds typed, single-purpose methods for the handful of admin/topology
+// operations this operator needs, each a thin wrapper over Client.Do. They're kept
+// separate from BuiltInTopologyService's own, more detailed response types (e.g. its
+// ServerStatData, FailoverConfig) -- those already serve the controllers package, and
+// duplicating them here would just be the same data reshaped for no benefit. Methods in
+// this file are for callers that want this package's Client directly instead of going
+// through BuiltInTopologyService.
+
+var joinServerMutation = `mutation
+	do_join_server(
+		$uri: String!,
+		$instance_uuid: String!,
+		$replicaset_uuid: String!,
+		$roles: [String!],
+		$vshard_group: String!
+	) {
+	joinInstanceResponse: join_server(
+		uri: $uri,
+		instance_uuid: $instance_uuid,
+		replicaset_uuid: $replicaset_uuid,
+		roles: $roles,
+		timeout: 10,
+		vshard_group: $vshard_group
+	)
+}`
+
+// JoinServerParams configures a Client.JoinServer call.
+type JoinServerParams struct {
+	URI            string
+	InstanceUUID   string
+	ReplicasetUUID string
+	Roles          []string
+	VshardGroup    string
+}
+
+// JoinServer issues Cartridge's join_server mutation, adding an instance to a
+// replicaset.
+func (c *Client) JoinServer(p JoinServerParams) (bool, error) {
+	var resp struct {
+		JoinInstanceResponse bool `json:"joinInstanceResponse"`
+	}
+
+	variables := map[string]interface{}{
+		"uri":             p.URI,
+		"instance_uuid":   p.InstanceUUID,
+		"replicaset_uuid": p.ReplicasetUUID,
+		"roles":           p.Roles,
+		"vshard_group":    p.VshardGroup,
+	}
+
+	err := c.Do(joinServerMutation, variables, &resp)
+	return resp.JoinInstanceResponse, err
+}
+
+var expelServerMutation = `mutation expelServer($uuid: String!) {
+	expel_instance: expel_server(uuid: $uuid)
+}`
+
+// ExpelServer issues Cartridge's expel_server mutation, removing an instance from the
+// cluster.
+func (c *Client) ExpelServer(instanceUUID string) (bool, error) {
+	var resp struct {
+		ExpelInstance bool `json:"expel_instance"`
+	}
+
+	err := c.Do(expelServerMutation, map[string]interface{}{"uuid": instanceUUID}, &resp)
+	return resp.ExpelInstance, err
+}
+
+var bootstrapVshardMutation = `mutation { bootstrapVshardResponse: bootstrap_vshard }`
+
+// BootstrapVshard issues Cartridge's bootstrap_vshard mutation.
+func (c *Client) BootstrapVshard() (bool, error) {
+	var resp struct {
+		BootstrapVshardResponse bool `json:"bootstrapVshardResponse"`
+	}
+
+	err := c.Do(bootstrapVshardMutation, nil, &resp)
+	return resp.BootstrapVshardResponse, err
+}
+
+var probeServerMutation = `mutation probeServer($uri: String!) {
+	probeServerResponse: probe_server(uri: $uri)
+}`
+
+// ProbeServer issues Cartridge's probe_server mutation, asking the current leader to
+// try connecting to uri -- the usual precondition before join_server will accept it.
+func (c *Client) ProbeServer(uri string) (bool, error) {
+	var resp struct {
+		ProbeServerResponse bool `json:"probeServerResponse"`
+	}
+
+	err := c.Do(probeServerMutation, map[string]interface{}{"uri": uri}, &resp)
+	return resp.ProbeServerResponse, err
+}
+
+var getTopologyQuery = `query {
+	servers {
+		uuid
+		uri
+		replicaset {
+			uuid
+			roles
+			status
+		}
+	}
+}`
+
+// TopologyServer is one entry of GetTopology's servers list.
+type TopologyServer struct {
+	UUID       string `json:"uuid"`
+	URI        string `json:"uri"`
+	Replicaset *struct {
+		UUID   string   `json:"uuid"`
+		Roles  []string `json:"roles"`
+		Status string   `json:"status"`
+	} `json:"replicaset"`
+}
+
+// GetTopology returns every server Cartridge currently knows about and the replicaset
+// each belongs to.
+func (c *Client) GetTopology() ([]*TopologyServer, error) {
+	var resp struct {
+		Servers []*TopologyServer `json:"servers"`
+	}
+
+	err := c.Do(getTopologyQuery, nil, &resp)
+	return resp.Servers, err
+}
+
+var setReplicasetWeightMutation = `mutation setReplicasetWeight($uuid: String!, $weight: Float!) {
+	editReplicasetResponse: edit_replicaset(uuid: $uuid, weight: $weight)
+}`
+
+// SetReplicasetWeight issues Cartridge's edit_replicaset mutation, setting only the
+// named replicaset's vshard bucket weight.
+func (c *Client) SetReplicasetWeight(replicasetUUID string, weight float64) (bool, error) {
+	var resp struct {
+		EditReplicasetResponse bool `json:"editReplicasetResponse"`
+	}
+
+	variables := map[string]interface{}{"uuid": replicasetUUID, "weight": weight}
+	err := c.Do(setReplicasetWeightMutation, variables, &resp)
+	return resp.EditReplicasetResponse, err
+}
+
+var configForceReapplyMutation = `mutation configForceReapply($uuids: [String!]) {
+	configForceReapplyResponse: config_force_reapply(uuids: $uuids)
+}`
+
+// ConfigForceReapply issues Cartridge's config_force_reapply mutation, having the named
+// instances (or, if uuids is empty, every instance) re-read and re-apply clusterwide
+// config from disk -- useful after a config file was edited out-of-band.
+func (c *Client) ConfigForceReapply(uuids []string) (bool, error) {
+	var resp struct {
+		ConfigForceReapplyResponse bool `json:"configForceReapplyResponse"`
+	}
+
+	err := c.Do(configForceReapplyMutation, map[string]interface{}{"uuids": uuids}, &resp)
+	return resp.ConfigForceReapplyResponse, err
+}
+
+var failoverParamsFields = `{
+	mode
+	state_provider
+}`
+
+var getFailoverParamsQuery = `query { cluster { failover_params ` + failoverParamsFields + ` } }`
+
+var setFailoverParamsMutation = `mutation setFailoverParams(
+	$mode: String!,
+	$state_provider: String,
+	$etcd2_params: FailoverStateProviderCfgInputEtcd2,
+	$tarantool_params: FailoverStateProviderCfgInputTarantool
+) {
+	cluster {
+		failover_params(
+			mode: $mode,
+			state_provider: $state_provider,
+			etcd2_params: $etcd2_params,
+			tarantool_params: $tarantool_params
+		) ` + failoverParamsFields + `
+	}
+}`
+
+// FailoverParams is Cartridge's cluster.failover_params, as returned by
+// GetFailoverParams and accepted by SetFailoverParams.
+type FailoverParams struct {
+	Mode            string                 `json:"mode"`
+	StateProvider   string                 `json:"state_provider,omitempty"`
+	ETCD2Params     map[string]interface{} `json:"etcd2_params,omitempty"`
+	TarantoolParams map[string]interface{} `json:"tarantool_params,omitempty"`
+}
+
+// GetFailoverParams issues Cartridge's cluster.failover_params query.
+func (c *Client) GetFailoverParams() (*FailoverParams, error) {
+	var resp struct {
+		Cluster *struct {
+			FailoverParams *FailoverParams `json:"failover_params"`
+		} `json:"cluster"`
+	}
+
+	if err := c.Do(getFailoverParamsQuery, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Cluster == nil {
+		return nil, nil
+	}
+
+	return resp.Cluster.FailoverParams, nil
+}
+
+// SetFailoverParams issues Cartridge's cluster.failover_params mutation and returns the
+// params Cartridge confirmed as active.
+func (c *Client) SetFailoverParams(p FailoverParams) (*FailoverParams, error) {
+	var resp struct {
+		Cluster *struct {
+			FailoverParams *FailoverParams `json:"failover_params"`
+		} `json:"cluster"`
+	}
+
+	variables := map[string]interface{}{
+		"mode":             p.Mode,
+		"state_provider":   p.StateProvider,
+		"etcd2_params":     p.ETCD2Params,
+		"tarantool_params": p.TarantoolParams,
+	}
+
+	if err := c.Do(setFailoverParamsMutation, variables, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Cluster == nil {
+		return nil, nil
+	}
+
+	return resp.Cluster.FailoverParams, nil
+}
+
+var getServerStatQuery = `query serverList {
+	serverStat: servers {
+		uuid
+		uri
+		statistics {
+			quotaSize: quota_size
+			arenaUsed: arena_used
+			bucketsCount: vshard_buckets_count
+			quota_used_ratio
+			arena_used_ratio
+			items_used_ratio
+		}
+	}
+}`
+
+// ServerStat is one entry of GetServerStat's result.
+type ServerStat struct {
+	UUID       string `json:"uuid"`
+	URI        string `json:"uri"`
+	Statistics struct {
+		ItemsUsedRatio string `json:"items_used_ratio"`
+		ArenaUsedRatio string `json:"arena_used_ratio"`
+		QuotaSize      int    `json:"quotaSize"`
+		ArenaUsed      int    `json:"arenaUsed"`
+		QuotaUsedRatio string `json:"quota_used_ratio"`
+		BucketsCount   int    `json:"bucketsCount"`
+	} `json:"statistics"`
+}
+
+// GetServerStat issues Cartridge's servers{statistics{...}} query.
+func (c *Client) GetServerStat() ([]*ServerStat, error) {
+	var resp struct {
+		ServerStat []*ServerStat `json:"serverStat"`
+	}
+
+	err := c.Do(getServerStatQuery, nil, &resp)
+	return resp.ServerStat, err
+}
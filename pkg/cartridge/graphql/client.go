@@ -0,0 +1,195 @@
+// Package graphql is a typed client for Cartridge's admin/topology GraphQL API: a
+// shared HTTP transport with retry/backoff and structured error surfacing, usable
+// independent of controllers/topology.BuiltInTopologyService, which delegates its own
+// request/retry machinery to a Client rather than keeping a second copy of it.
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Error is a structured error from one entry of a Cartridge GraphQL response's
+// errors[] array, built from its message and extensions object. It replaces
+// substring-matching on err.Error() (fragile across Cartridge versions and
+// localizations) as the basis for sentinel checks like IsRetryable.
+type Error struct {
+	Code         string
+	ClassName    string
+	Message      string
+	InstanceUUID string
+}
+
+func (e *Error) Error() string {
+	if e.ClassName == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.ClassName, e.Message)
+}
+
+// wireError is the shape of one entry in a Cartridge GraphQL response's errors[] array.
+type wireError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code         string `json:"code"`
+		ClassName    string `json:"class_name"`
+		InstanceUUID string `json:"instance_uuid"`
+	} `json:"extensions"`
+}
+
+func (e wireError) asError() *Error {
+	return &Error{
+		Code:         e.Extensions.Code,
+		ClassName:    e.Extensions.ClassName,
+		Message:      e.Message,
+		InstanceUUID: e.Extensions.InstanceUUID,
+	}
+}
+
+// envelope is the shape of a Cartridge GraphQL response, decoded directly rather than
+// through github.com/machinebox/graphql's Client.Run, which discards errors[].extensions
+// and keeps only the first error's message.
+type envelope struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []wireError     `json:"errors"`
+}
+
+// RetryPolicy configures WithRetry's exponential backoff.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+}
+
+// DefaultRetryPolicy is used by WithRetry when called with a zero-value RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: 200 * time.Millisecond}
+
+// WithRetry runs op, retrying with exponential backoff on errors IsRetryable
+// classifies as transient, and returning immediately on any other error so a terminal
+// failure (e.g. "already joined") isn't needlessly retried. A zero-value policy falls
+// back to DefaultRetryPolicy.
+func WithRetry(policy RetryPolicy, op func() error) error {
+	if policy.MaxRetries == 0 && policy.BaseDelay == 0 {
+		policy = DefaultRetryPolicy
+	}
+
+	var err error
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		err = op()
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+		if attempt == policy.MaxRetries {
+			break
+		}
+		time.Sleep(policy.BaseDelay * time.Duration(int(1)<<attempt))
+	}
+
+	return err
+}
+
+// Client issues GraphQL requests against a Cartridge admin/topology endpoint over
+// HTTPClient (whose Transport/Jar, if any, are responsible for session auth), decoding
+// the errors[] array into a structured *Error instead of an opaque message string.
+type Client struct {
+	Endpoint    string
+	HTTPClient  *http.Client
+	RetryPolicy RetryPolicy
+}
+
+// NewClient builds a Client posting to endpoint via httpClient.
+func NewClient(endpoint string, httpClient *http.Client, retryPolicy RetryPolicy) *Client {
+	return &Client{Endpoint: endpoint, HTTPClient: httpClient, RetryPolicy: retryPolicy}
+}
+
+// Do POSTs query/variables to c.Endpoint, retrying a transient failure per
+// c.RetryPolicy, and decodes the result into out (skipped if nil or the response
+// carried no data).
+func (c *Client) Do(query string, variables map[string]interface{}, out interface{}) error {
+	return WithRetry(c.RetryPolicy, func() error {
+		return c.doOnce(query, variables, out)
+	})
+}
+
+func (c *Client) doOnce(query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	rawResp, err := c.HTTPClient.Post(c.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer rawResp.Body.Close()
+
+	env := &envelope{}
+	if err := json.NewDecoder(rawResp.Body).Decode(env); err != nil {
+		return fmt.Errorf("decoding topology response: %w", err)
+	}
+
+	if len(env.Errors) > 0 {
+		return env.Errors[0].asError()
+	}
+
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return fmt.Errorf("decoding topology response data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// IsConnectionError reports whether err means the GraphQL endpoint itself was
+// unreachable (connection refused/reset, DNS failure, timeout), as opposed to a
+// GraphQL-level error from an endpoint that answered.
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsBucketRebalancing reports whether err is Cartridge reporting that vshard is
+// currently rebalancing buckets, a condition callers typically wait out rather than
+// treat as a failure.
+func IsBucketRebalancing(err error) bool {
+	var topoErr *Error
+	if !errors.As(err, &topoErr) {
+		return false
+	}
+
+	return topoErr.ClassName == "BucketsRebalancing" || strings.Contains(strings.ToLower(topoErr.Message), "rebalanc")
+}
+
+// IsPatchInProgress reports whether err is Cartridge rejecting a request because
+// another clusterwide config patch is already being applied.
+func IsPatchInProgress(err error) bool {
+	var topoErr *Error
+	if !errors.As(err, &topoErr) {
+		return false
+	}
+
+	return topoErr.ClassName == "PatchInProgress" || strings.Contains(strings.ToLower(topoErr.Message), "patch in progress")
+}
+
+// IsRetryable reports whether err represents a transient condition worth retrying: the
+// endpoint being unreachable, vshard currently rebalancing buckets, or a concurrent
+// config patch still in flight. Callers with their own terminal-error sentinels (e.g.
+// "already joined") should check those first.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return IsConnectionError(err) || IsBucketRebalancing(err) || IsPatchInProgress(err)
+}
@@ -0,0 +1,77 @@
+// Package config provides a ComponentConfig-style configuration file for the
+// operator, replacing the constants that used to be scattered across the
+// individual controller packages.
+package config
+
+import (
+	"io/ioutil"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FinalizersConfig names the finalizers the operator places on resources it
+// manages, so installs can namespace them alongside other operators sharing
+// the same cluster.
+type FinalizersConfig struct {
+	// Replicaset is the finalizer used to block StatefulSet deletion until its
+	// Tarantool instances have been expelled from the cluster.
+	Replicaset string `json:"replicaset,omitempty"`
+}
+
+// TarantoolOperatorConfig is the root of the operator's configuration file,
+// read by Load. Nothing in the operator calls Load at manager start; the role
+// controller is its only consumer, receiving it via AddWithConfig(mgr, cfg)
+// (Add passes Default()).
+type TarantoolOperatorConfig struct {
+	// UUIDNamespace seeds the SHA1 UUIDs generated for replicaset and instance
+	// identifiers, so that names are stable across reconciles.
+	UUIDNamespace string `json:"uuidNamespace,omitempty"`
+	// Finalizers configures the finalizer names used by the controllers.
+	Finalizers FinalizersConfig `json:"finalizers,omitempty"`
+	// LabelPrefix names the prefix of the operator's label and annotation keys.
+	// No controller reads it: the role controller's keys are fixed to tarantool.io/*.
+	LabelPrefix string `json:"labelPrefix,omitempty"`
+	// LeaderElection enables leader election between operator replicas. No
+	// controller or manager setup reads it.
+	LeaderElection bool `json:"leaderElection,omitempty"`
+	// MetricsBindAddress is the address the metrics endpoint binds to. No manager
+	// setup reads it.
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+	// ExpelTimeout is how long the role controller waits before requeuing a Role
+	// whose replicaset expel failed after its bounded expel_instance retries.
+	ExpelTimeout time.Duration `json:"expelTimeout,omitempty"`
+	// ReconcileConcurrency is the number of concurrent reconciles each
+	// controller is allowed to run.
+	ReconcileConcurrency int `json:"reconcileConcurrency,omitempty"`
+}
+
+// Default returns the configuration that matches the operator's historical,
+// hard-coded behavior.
+func Default() *TarantoolOperatorConfig {
+	return &TarantoolOperatorConfig{
+		UUIDNamespace:        "C4FA9F56-A49A-4384-8BEE-9A476725973F",
+		Finalizers:           FinalizersConfig{Replicaset: "tarantool.io/replicaset"},
+		LabelPrefix:          "tarantool.io",
+		MetricsBindAddress:   ":8080",
+		ExpelTimeout:         15 * time.Second,
+		ReconcileConcurrency: 1,
+	}
+}
+
+// Load reads a TarantoolOperatorConfig from a YAML file at path, starting
+// from Default() so unset fields keep their historical values.
+func Load(path string) (*TarantoolOperatorConfig, error) {
+	cfg := Default()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -1,14 +1,22 @@
 package role
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"time"
 
 	goerrors "errors"
 
+	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	tarantoolv1alpha1 "github.com/tarantool/tarantool-operator/pkg/apis/tarantool/v1alpha1"
+	"github.com/tarantool/tarantool-operator/pkg/controller/config"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,10 +29,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"k8s.io/client-go/tools/record"
 )
 
 var log = logf.Log.WithName("controller_role")
-var space = uuid.MustParse("C4FA9F56-A49A-4384-8BEE-9A476725973F")
+
+// expellingCondition marks a StatefulSet as being drained of its Tarantool
+// instances before it is deleted.
+const expellingCondition = "tarantool.io/expelling"
+
+// expelRetryAttempts/expelRetryBackoff bound how long Reconcile will block
+// waiting for a single expel_instance call to succeed before giving up and
+// requeuing.
+const expelRetryAttempts = 5
+
+var expelRetryBackoff = time.Second
 
 type ResponseError struct {
 	Message string `json:"message"`
@@ -38,6 +58,17 @@ type ExpelResponse struct {
 	Data   *ExpelResponseData `json:"data,omitempty"`
 }
 
+// OrphanRoleError is returned by Reconcile when a Role has no owner
+// references, so callers can distinguish it from other reconcile failures
+// instead of matching on an error string.
+type OrphanRoleError struct {
+	Name string
+}
+
+func (e *OrphanRoleError) Error() string {
+	return fmt.Sprintf("role %s has no owner references", e.Name)
+}
+
 /**
 * USER ACTION REQUIRED: This is a scaffold file intended for the user to modify with their own Controller
 * business logic.  Delete these comments after modifying this file.*
@@ -46,18 +77,36 @@ type ExpelResponse struct {
 // Add creates a new Role Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	return AddWithConfig(mgr, config.Default())
+}
+
+// AddWithConfig is like Add, but lets callers override the operator's
+// ComponentConfig instead of relying on the historical, hard-coded defaults.
+func AddWithConfig(mgr manager.Manager, cfg *config.TarantoolOperatorConfig) error {
+	return add(mgr, newReconciler(mgr, cfg))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileRole{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+func newReconciler(mgr manager.Manager, cfg *config.TarantoolOperatorConfig) reconcile.Reconciler {
+	uuidNamespace := uuid.MustParse(cfg.UUIDNamespace)
+	return &ReconcileRole{
+		client:        mgr.GetClient(),
+		scheme:        mgr.GetScheme(),
+		recorder:      mgr.GetRecorder("role-controller"),
+		cfg:           cfg,
+		uuidNamespace: uuidNamespace,
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	opts := controller.Options{Reconciler: r}
+	if rr, ok := r.(*ReconcileRole); ok && rr.cfg != nil && rr.cfg.ReconcileConcurrency > 0 {
+		opts.MaxConcurrentReconciles = rr.cfg.ReconcileConcurrency
+	}
+
 	// Create a new controller
-	c, err := controller.New("role-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("role-controller", mgr, opts)
 	if err != nil {
 		return err
 	}
@@ -68,7 +117,13 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	err = c.Watch(&source.Kind{Type: &appsv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{
+	// We only need name/labels/ownerRefs to enqueue the owning Role, so watch
+	// StatefulSet metadata only. This keeps the informer cache from holding a
+	// full copy (including pod template, env, volumes...) of every child
+	// StatefulSet in memory.
+	stsMeta := &metav1.PartialObjectMetadata{}
+	stsMeta.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+	err = c.Watch(&source.Kind{Type: stsMeta}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &tarantoolv1alpha1.Role{},
 	})
@@ -79,9 +134,22 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	err = c.Watch(&source.Kind{Type: &tarantoolv1alpha1.ReplicasetTemplate{}}, &handler.EnqueueRequestsFromMapFunc{
 		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
 			rec := r.(*ReconcileRole)
+
+			clusterID := a.Meta.GetLabels()["tarantool.io/cluster-id"]
 			roleList := &tarantoolv1alpha1.RoleList{}
-			if err := rec.client.List(context.TODO(), &client.ListOptions{}, roleList); err != nil {
-				log.Info("FUCK")
+			listOpts := &client.ListOptions{Namespace: a.Meta.GetNamespace()}
+			if clusterID != "" {
+				s, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"tarantool.io/cluster-id": clusterID}})
+				if err != nil {
+					log.Error(err, "failed to build ReplicasetTemplate mapper selector")
+					return []reconcile.Request{}
+				}
+				listOpts.LabelSelector = s
+			}
+
+			if err := rec.client.List(context.TODO(), listOpts, roleList); err != nil {
+				log.Error(err, "failed to list roles for ReplicasetTemplate mapper")
+				return []reconcile.Request{}
 			}
 
 			res := []reconcile.Request{}
@@ -96,6 +164,9 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 			return res
 		}),
 	})
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -107,8 +178,11 @@ var _ reconcile.Reconciler = &ReconcileRole{}
 type ReconcileRole struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	client client.Client
-	scheme *runtime.Scheme
+	client        client.Client
+	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
+	cfg           *config.TarantoolOperatorConfig
+	uuidNamespace uuid.UUID
 }
 
 // Reconcile reads that state of the cluster for a Role object and makes changes based on the state read
@@ -132,7 +206,7 @@ func (r *ReconcileRole) Reconcile(request reconcile.Request) (reconcile.Result,
 	}
 
 	if len(role.GetOwnerReferences()) == 0 {
-		return reconcile.Result{}, goerrors.New(fmt.Sprintf("Orphan role %s", role.GetName()))
+		return reconcile.Result{}, &OrphanRoleError{Name: role.GetName()}
 	}
 
 	templateSelector, err := metav1.LabelSelectorAsSelector(role.Spec.Selector)
@@ -170,9 +244,16 @@ func (r *ReconcileRole) Reconcile(request reconcile.Request) (reconcile.Result,
 				return reconcile.Result{}, err
 			}
 
+			if err := r.expelStatefulSet(reqLogger, role, sts); err != nil {
+				reqLogger.Info("failed to expel replicaset, will retry", "StatefulSet.Name", sts.Name, "error", err.Error())
+				r.recorder.Eventf(role, corev1.EventTypeWarning, "ExpelFailed", "failed to expel replicaset %s: %s", sts.Name, err)
+				return reconcile.Result{RequeueAfter: r.cfg.ExpelTimeout}, nil
+			}
+
 			if err := r.client.Delete(context.TODO(), sts); err != nil {
 				return reconcile.Result{}, err
 			}
+			r.recorder.Eventf(role, corev1.EventTypeNormal, "ReplicasetExpelled", "expelled and removed replicaset %s", sts.Name)
 		}
 	}
 
@@ -181,9 +262,12 @@ func (r *ReconcileRole) Reconcile(request reconcile.Request) (reconcile.Result,
 		return reconcile.Result{}, err
 	}
 	if len(templateList.Items) == 0 {
-		return reconcile.Result{}, goerrors.New("no template")
+		return reconcile.Result{}, fmt.Errorf("no ReplicasetTemplate matches role %s", role.GetName())
 	}
 	template := templateList.Items[0]
+	if template.Spec.Base == nil {
+		return reconcile.Result{}, fmt.Errorf("ReplicasetTemplate %s has no base StatefulSetSpec", template.GetName())
+	}
 
 	if len(stsList.Items) < int(*role.Spec.NumReplicasets) {
 		for i := 0; i < int(*role.Spec.NumReplicasets); i++ {
@@ -192,7 +276,7 @@ func (r *ReconcileRole) Reconcile(request reconcile.Request) (reconcile.Result,
 			sts.Namespace = request.Namespace
 
 			if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: sts.Namespace, Name: sts.Name}, sts); err != nil {
-				sts = CreateStatefulSetFromTemplate(fmt.Sprintf("%s-%d", role.Name, i), role, &template)
+				sts = CreateStatefulSetFromTemplate(r.uuidNamespace, i, fmt.Sprintf("%s-%d", role.Name, i), role, &template)
 				if err := controllerutil.SetControllerReference(role, sts, r.scheme); err != nil {
 					return reconcile.Result{}, err
 				}
@@ -203,9 +287,18 @@ func (r *ReconcileRole) Reconcile(request reconcile.Request) (reconcile.Result,
 		}
 	}
 
-	for _, sts := range stsList.Items {
-		if template.Spec.Replicas != sts.Spec.Replicas {
-			sts.Spec.Replicas = template.Spec.Replicas
+	for i, sts := range stsList.Items {
+		// stsList is not in ordinal order, so the shard ordinal is read from the name.
+		ordinal := i
+		if _, err := fmt.Sscanf(sts.Name, role.Name+"-%d", &ordinal); err != nil {
+			ordinal = i
+		}
+		effective := EffectiveSpecForShard(&template, ordinal)
+		if effective == nil {
+			continue
+		}
+		if effective.Replicas != sts.Spec.Replicas {
+			sts.Spec.Replicas = effective.Replicas
 			if err := r.client.Update(context.TODO(), &sts); err != nil {
 				return reconcile.Result{}, err
 			}
@@ -215,30 +308,221 @@ func (r *ReconcileRole) Reconcile(request reconcile.Request) (reconcile.Result,
 	return reconcile.Result{}, nil
 }
 
-func CreateStatefulSetFromTemplate(name string, role *tarantoolv1alpha1.Role, rs *tarantoolv1alpha1.ReplicasetTemplate) *appsv1.StatefulSet {
+// EffectiveSpecForShard merges rs.Spec.Base with the first ReplicasetTemplateOverride that
+// matches the given shard ordinal, returning the StatefulSetSpec that should be applied to it.
+// It returns nil if rs.Spec.Base is unset.
+func EffectiveSpecForShard(rs *tarantoolv1alpha1.ReplicasetTemplate, ordinal int) *appsv1.StatefulSetSpec {
+	if rs.Spec.Base == nil {
+		return nil
+	}
+	spec := rs.Spec.Base.DeepCopy()
+
+	for _, override := range rs.Spec.Overrides {
+		if override.Ordinal == nil || int(*override.Ordinal) != ordinal {
+			continue
+		}
+		mergeStatefulSetSpec(spec, override.Patch)
+		break
+	}
+
+	return spec
+}
+
+// mergeStatefulSetSpec copies the fields patch sets onto base, in place.
+func mergeStatefulSetSpec(base, patch *appsv1.StatefulSetSpec) {
+	if patch == nil {
+		return
+	}
+	if patch.Replicas != nil {
+		base.Replicas = patch.Replicas
+	}
+	if len(patch.Template.Spec.Containers) > 0 {
+		base.Template.Spec.Containers = patch.Template.Spec.Containers
+	}
+	if patch.Template.Spec.NodeSelector != nil {
+		base.Template.Spec.NodeSelector = patch.Template.Spec.NodeSelector
+	}
+	if len(patch.Template.Spec.Tolerations) > 0 {
+		base.Template.Spec.Tolerations = patch.Template.Spec.Tolerations
+	}
+	if len(patch.VolumeClaimTemplates) > 0 {
+		base.VolumeClaimTemplates = patch.VolumeClaimTemplates
+	}
+}
+
+// HashSpec returns a stable hex-encoded sha256 hash of a StatefulSetSpec, stamped on
+// each new StatefulSet as tarantool.io/template-spec-hash. Reconcile does not compare
+// it later: on existing StatefulSets only Replicas is brought in line with the template.
+func HashSpec(spec *appsv1.StatefulSetSpec) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%x", sum), nil
+}
+
+// CreateStatefulSetFromTemplate builds the StatefulSet for shard `ordinal`, merging
+// rs.Spec.Base with any matching override and stamping the template-version and
+// template-spec-hash annotations. Reconcile does not read these annotations back.
+func CreateStatefulSetFromTemplate(uuidNamespace uuid.UUID, ordinal int, name string, role *tarantoolv1alpha1.Role, rs *tarantoolv1alpha1.ReplicasetTemplate) *appsv1.StatefulSet {
+	effective := EffectiveSpecForShard(rs, ordinal)
+	if effective == nil {
+		effective = &appsv1.StatefulSetSpec{}
+	}
+
 	sts := &appsv1.StatefulSet{
-		Spec: *rs.Spec,
+		Spec: *effective,
 	}
 	sts.Name = name
 	sts.Namespace = role.GetNamespace()
 	sts.ObjectMeta.Labels = role.GetLabels()
+	if sts.Spec.Template.Labels == nil {
+		sts.Spec.Template.Labels = make(map[string]string)
+	}
 	for k, v := range role.GetLabels() {
 		sts.Spec.Template.Labels[k] = v
 	}
 	sts.Spec.ServiceName = role.GetAnnotations()["tarantool.io/cluster-id"]
-	replicasetUUID := uuid.NewSHA1(space, []byte(sts.GetName()))
+	replicasetUUID := uuid.NewSHA1(uuidNamespace, []byte(sts.GetName()))
 	sts.ObjectMeta.Labels["tarantool.io/replicaset-uuid"] = replicasetUUID.String()
 	sts.Spec.Template.Labels["tarantool.io/replicaset-uuid"] = replicasetUUID.String()
 
+	if sts.ObjectMeta.Annotations == nil {
+		sts.ObjectMeta.Annotations = make(map[string]string)
+	}
+	sts.ObjectMeta.Annotations["tarantool.io/template-version"] = rs.Spec.TemplateVersion
+	if hash, err := HashSpec(effective); err == nil {
+		sts.ObjectMeta.Annotations["tarantool.io/template-spec-hash"] = hash
+	}
+
 	return sts
 }
 
-func RemoveFinalizer(finalizers []string) []string {
+// RemoveFinalizer returns finalizers with every occurrence of name removed.
+func RemoveFinalizer(finalizers []string, name string) []string {
 	newFinalizers := []string{}
 	for _, v := range finalizers {
-		if v != "tarantool.io/replicaset" {
+		if v != name {
 			newFinalizers = append(newFinalizers, v)
 		}
 	}
 	return newFinalizers
 }
+
+// expelStatefulSet marks sts as expelling, calls the cartridge admin API to
+// expel every one of its Tarantool instances, and only then drops the
+// tarantool.io/replicaset finalizer so the StatefulSet can be deleted. It is
+// safe to call repeatedly: instances that are already gone are skipped.
+func (r *ReconcileRole) expelStatefulSet(reqLogger logr.Logger, role *tarantoolv1alpha1.Role, sts *appsv1.StatefulSet) error {
+	annotations := sts.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if annotations[expellingCondition] != "1" {
+		annotations[expellingCondition] = "1"
+		sts.SetAnnotations(annotations)
+		if err := r.client.Update(context.TODO(), sts); err != nil {
+			return err
+		}
+	}
+
+	adminEndpoint, err := r.resolveAdminEndpoint(role)
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	podSelector := &metav1.LabelSelector{MatchLabels: sts.GetLabels()}
+	s, err := metav1.LabelSelectorAsSelector(podSelector)
+	if err != nil {
+		return err
+	}
+	if err := r.client.List(context.TODO(), &client.ListOptions{LabelSelector: s, Namespace: sts.GetNamespace()}, podList); err != nil {
+		return err
+	}
+
+	for _, pod := range podList.Items {
+		instanceUUID, ok := pod.GetLabels()["tarantool.io/instance-uuid"]
+		if !ok {
+			continue
+		}
+
+		reqLogger.Info("expelling instance", "Pod.Name", pod.GetName(), "instance-uuid", instanceUUID)
+		if err := expelInstance(adminEndpoint, instanceUUID); err != nil {
+			return err
+		}
+	}
+
+	sts.SetFinalizers(RemoveFinalizer(sts.GetFinalizers(), r.cfg.Finalizers.Replicaset))
+	return r.client.Update(context.TODO(), sts)
+}
+
+// resolveAdminEndpoint looks up the cartridge admin Service for role's cluster,
+// selected by a tarantool.io/cluster-id label equal to the Role's
+// tarantool.io/cluster-id annotation.
+func (r *ReconcileRole) resolveAdminEndpoint(role *tarantoolv1alpha1.Role) (string, error) {
+	clusterID := role.GetAnnotations()["tarantool.io/cluster-id"]
+	if clusterID == "" {
+		return "", goerrors.New("role has no tarantool.io/cluster-id annotation, cannot resolve admin endpoint")
+	}
+
+	svcList := &corev1.ServiceList{}
+	s, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"tarantool.io/cluster-id": clusterID}})
+	if err != nil {
+		return "", err
+	}
+	if err := r.client.List(context.TODO(), &client.ListOptions{LabelSelector: s, Namespace: role.GetNamespace()}, svcList); err != nil {
+		return "", err
+	}
+	if len(svcList.Items) == 0 {
+		return "", fmt.Errorf("no cartridge admin service found for cluster %s", clusterID)
+	}
+
+	svc := svcList.Items[0]
+	return fmt.Sprintf("http://%s.%s:8081/admin/api", svc.GetName(), svc.GetNamespace()), nil
+}
+
+// expelInstance calls the cartridge admin GraphQL endpoint to expel a single
+// instance, retrying on failure with a bounded, linear backoff.
+func expelInstance(adminEndpoint string, instanceUUID string) error {
+	mutation := fmt.Sprintf(`mutation { expel_instance(uuid: "%s") }`, instanceUUID)
+	body, err := json.Marshal(map[string]string{"query": mutation})
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < expelRetryAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(expelRetryBackoff * time.Duration(attempt))
+		}
+
+		resp, err := http.Post(adminEndpoint, "application/json", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		expelResp := &ExpelResponse{}
+		err = json.NewDecoder(resp.Body).Decode(expelResp)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if len(expelResp.Errors) > 0 {
+			lastErr = goerrors.New(expelResp.Errors[0].Message)
+			continue
+		}
+
+		if expelResp.Data != nil && expelResp.Data.ExpelInstance {
+			return nil
+		}
+
+		lastErr = fmt.Errorf("expel_instance returned false for instance %s", instanceUUID)
+	}
+
+	return fmt.Errorf("failed to expel instance %s after %d attempts: %w", instanceUUID, expelRetryAttempts, lastErr)
+}
@@ -0,0 +1,199 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/uuid"
+	helpers "github.com/tarantool/tarantool-operator/test/helpers"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	tarantoolv1alpha1 "github.com/tarantool/tarantool-operator/pkg/apis/tarantool/v1alpha1"
+	"github.com/tarantool/tarantool-operator/pkg/controller/config"
+)
+
+// These specs drive ReconcileRole.Reconcile directly, rather than going
+// through a running manager, so that the assertions below don't depend on
+// envtest scheduling timing.
+var _ = Describe("ReconcileRole.Reconcile", func() {
+	var (
+		namespace = "default"
+		ctx       = context.TODO()
+		cfg       = config.Default()
+		r         *ReconcileRole
+	)
+
+	BeforeEach(func() {
+		r = &ReconcileRole{
+			client:        k8sClient,
+			scheme:        k8sClient.Scheme(),
+			recorder:      record.NewFakeRecorder(100),
+			cfg:           cfg,
+			uuidNamespace: uuid.MustParse(cfg.UUIDNamespace),
+		}
+	})
+
+	It("creates one StatefulSet per shard with a deterministic replicaset-uuid", func() {
+		roleName := fmt.Sprintf("test-role-%s", RandStringRunes(4))
+		rsTemplateName := fmt.Sprintf("test-rs-%s", RandStringRunes(4))
+		clusterId := "t"
+
+		role := helpers.NewRole(helpers.RoleParams{
+			Name:           roleName,
+			Namespace:      namespace,
+			RolesToAssign:  "[]",
+			RsNum:          int32(3),
+			RsTemplateName: rsTemplateName,
+			ClusterId:      clusterId,
+		})
+		role.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v0", Kind: "mockRef", Name: "mockRef", UID: "-"}})
+		Expect(k8sClient.Create(ctx, &role)).NotTo(HaveOccurred())
+
+		rsTemplate := helpers.NewReplicasetTemplate(helpers.ReplicasetTemplateParams{
+			Name:          rsTemplateName,
+			Namespace:     namespace,
+			RoleName:      roleName,
+			RolesToAssign: "[]",
+		})
+		Expect(k8sClient.Create(ctx, &rsTemplate)).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(reconcile.Request{NamespacedName: client.ObjectKey{Name: roleName, Namespace: namespace}})
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 3; i++ {
+			stsName := fmt.Sprintf("%s-%d", roleName, i)
+			sts := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: stsName, Namespace: namespace}, sts)).NotTo(HaveOccurred())
+
+			wantUUID := uuid.NewSHA1(r.uuidNamespace, []byte(stsName)).String()
+			Expect(sts.GetLabels()["tarantool.io/replicaset-uuid"]).To(Equal(wantUUID))
+			Expect(sts.Spec.Template.GetLabels()["tarantool.io/replicaset-uuid"]).To(Equal(wantUUID))
+		}
+	})
+
+	It("downscale deletes only the highest-ordinal StatefulSet", func() {
+		roleName := fmt.Sprintf("test-role-%s", RandStringRunes(4))
+		rsTemplateName := fmt.Sprintf("test-rs-%s", RandStringRunes(4))
+		clusterId := fmt.Sprintf("cluster-%s", RandStringRunes(4))
+
+		role := helpers.NewRole(helpers.RoleParams{
+			Name:           roleName,
+			Namespace:      namespace,
+			RolesToAssign:  "[]",
+			RsNum:          int32(2),
+			RsTemplateName: rsTemplateName,
+			ClusterId:      clusterId,
+		})
+		role.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v0", Kind: "mockRef", Name: "mockRef", UID: "-"}})
+		role.Annotations["tarantool.io/cluster-id"] = clusterId
+		Expect(k8sClient.Create(ctx, &role)).NotTo(HaveOccurred())
+
+		rsTemplate := helpers.NewReplicasetTemplate(helpers.ReplicasetTemplateParams{
+			Name:          rsTemplateName,
+			Namespace:     namespace,
+			RoleName:      roleName,
+			RolesToAssign: "[]",
+		})
+		Expect(k8sClient.Create(ctx, &rsTemplate)).NotTo(HaveOccurred())
+
+		adminSvc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-admin", clusterId),
+				Namespace: namespace,
+				Labels:    map[string]string{"tarantool.io/cluster-id": clusterId},
+			},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 8081}}},
+		}
+		Expect(k8sClient.Create(ctx, adminSvc)).NotTo(HaveOccurred())
+
+		req := reconcile.Request{NamespacedName: client.ObjectKey{Name: roleName, Namespace: namespace}}
+		_, err := r.Reconcile(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s-0", roleName), Namespace: namespace}, &appsv1.StatefulSet{})).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s-1", roleName), Namespace: namespace}, &appsv1.StatefulSet{})).NotTo(HaveOccurred())
+
+		role.Spec.NumReplicasets = func(i int32) *int32 { return &i }(1)
+		Expect(k8sClient.Update(ctx, &role)).NotTo(HaveOccurred())
+
+		_, err = r.Reconcile(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s-0", roleName), Namespace: namespace}, &appsv1.StatefulSet{})).NotTo(HaveOccurred())
+		err = k8sClient.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s-1", roleName), Namespace: namespace}, &appsv1.StatefulSet{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("propagates replica-count drift on the template to existing StatefulSets", func() {
+		roleName := fmt.Sprintf("test-role-%s", RandStringRunes(4))
+		rsTemplateName := fmt.Sprintf("test-rs-%s", RandStringRunes(4))
+		clusterId := "t"
+
+		role := helpers.NewRole(helpers.RoleParams{
+			Name:           roleName,
+			Namespace:      namespace,
+			RolesToAssign:  "[]",
+			RsNum:          int32(1),
+			RsTemplateName: rsTemplateName,
+			ClusterId:      clusterId,
+		})
+		role.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v0", Kind: "mockRef", Name: "mockRef", UID: "-"}})
+		Expect(k8sClient.Create(ctx, &role)).NotTo(HaveOccurred())
+
+		rsTemplate := helpers.NewReplicasetTemplate(helpers.ReplicasetTemplateParams{
+			Name:          rsTemplateName,
+			Namespace:     namespace,
+			RoleName:      roleName,
+			RolesToAssign: "[]",
+		})
+		Expect(k8sClient.Create(ctx, &rsTemplate)).NotTo(HaveOccurred())
+
+		req := reconcile.Request{NamespacedName: client.ObjectKey{Name: roleName, Namespace: namespace}}
+		_, err := r.Reconcile(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: rsTemplateName, Namespace: namespace}, &rsTemplate)).NotTo(HaveOccurred())
+		drifted := int32(5)
+		rsTemplate.Spec.Base.Replicas = &drifted
+		Expect(k8sClient.Update(ctx, &rsTemplate)).NotTo(HaveOccurred())
+
+		_, err = r.Reconcile(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		sts := &appsv1.StatefulSet{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s-0", roleName), Namespace: namespace}, sts)).NotTo(HaveOccurred())
+		Expect(*sts.Spec.Replicas).To(Equal(drifted))
+	})
+
+	It("returns a typed error for an orphan Role instead of a bare string", func() {
+		roleName := fmt.Sprintf("test-role-%s", RandStringRunes(4))
+
+		role := helpers.NewRole(helpers.RoleParams{
+			Name:           roleName,
+			Namespace:      namespace,
+			RolesToAssign:  "[]",
+			RsNum:          int32(1),
+			RsTemplateName: "does-not-matter",
+			ClusterId:      "t",
+		})
+		// Deliberately no owner reference set.
+		Expect(k8sClient.Create(ctx, &role)).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(reconcile.Request{NamespacedName: client.ObjectKey{Name: roleName, Namespace: namespace}})
+		Expect(err).To(HaveOccurred())
+
+		var orphanErr *OrphanRoleError
+		Expect(errors.As(err, &orphanErr)).To(BeTrue(), "expected an *OrphanRoleError, got %T: %v", err, err)
+		Expect(orphanErr.Name).To(Equal(roleName))
+	})
+})
@@ -120,7 +120,7 @@ var _ = Describe("role_controller unit testing", func() {
 				).NotTo(HaveOccurred(), "failed to get ReplicasetTemplate")
 
 				rsTemplate.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] = newRolesToAssign
-				rsTemplate.Spec.Template.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] = newRolesToAssign
+				rsTemplate.Spec.Base.Template.ObjectMeta.Annotations["tarantool.io/rolesToAssign"] = newRolesToAssign
 				Expect(
 					k8sClient.Update(ctx, rsTemplate),
 				).NotTo(HaveOccurred(), "failed to update ReplicasetTemplate")
@@ -169,7 +169,7 @@ var _ = Describe("role_controller unit testing", func() {
 					k8sClient.Get(ctx, client.ObjectKey{Name: rsTemplateName, Namespace: namespace}, rsTemplate),
 				).NotTo(HaveOccurred(), "failed to get ReplicasetTemplate")
 
-				vars := rsTemplate.Spec.Template.Spec.Containers[0].Env
+				vars := rsTemplate.Spec.Base.Template.Spec.Containers[0].Env
 				for i := range vars {
 					if vars[i].Name == "TARANTOOL_MEMTX_MEMORY" {
 						vars[i].Value = value
@@ -214,8 +214,8 @@ var _ = Describe("role_controller unit testing", func() {
 					k8sClient.Get(ctx, client.ObjectKey{Name: rsTemplateName, Namespace: namespace}, rsTemplate),
 				).NotTo(HaveOccurred(), "failed to get ReplicasetTemplate")
 
-				rsTemplate.Spec.Template.Spec.Containers[0].Env = append(
-					rsTemplate.Spec.Template.Spec.Containers[0].Env,
+				rsTemplate.Spec.Base.Template.Spec.Containers[0].Env = append(
+					rsTemplate.Spec.Base.Template.Spec.Containers[0].Env,
 					corev1.EnvVar{Name: newVarName, Value: newVarValue},
 				)
 
@@ -257,10 +257,10 @@ var _ = Describe("role_controller unit testing", func() {
 					return append(s[:i], s[i+1:]...)
 				}
 
-				for i, v := range rsTemplate.Spec.Template.Spec.Containers[0].Env {
+				for i, v := range rsTemplate.Spec.Base.Template.Spec.Containers[0].Env {
 					if v.Name == varName {
-						rsTemplate.Spec.Template.Spec.Containers[0].Env = removeFromSlice(
-							rsTemplate.Spec.Template.Spec.Containers[0].Env, i)
+						rsTemplate.Spec.Base.Template.Spec.Containers[0].Env = removeFromSlice(
+							rsTemplate.Spec.Base.Template.Spec.Containers[0].Env, i)
 						break
 					}
 				}
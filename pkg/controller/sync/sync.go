@@ -0,0 +1,124 @@
+// Package sync runs a manager-wide scheduled job that periodically re-syncs every
+// Cluster, instead of each Cluster gating the work through its own Spec.Maintenance
+// cron window. It's deduped by namespace/name through a rate-limiting workqueue, so a
+// Cluster already queued for a sync isn't queued twice before it's processed.
+package sync
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+)
+
+// defaultInterval is how often every Cluster is re-enqueued when Options.Interval is unset.
+const defaultInterval = 5 * time.Minute
+
+// Options configures a Syncer. Use BindFlags to wire them to a manager binary's flags.
+type Options struct {
+	// Interval is how often every Cluster's namespace/name is re-enqueued. Defaults to
+	// 5 minutes.
+	Interval time.Duration
+}
+
+// BindFlags registers Options' fields on fs, so a manager binary can expose them as
+// command-line flags before calling flag.Parse.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&o.Interval, "sync-interval", defaultInterval, "how often every Cluster is re-enqueued for a scheduled sync")
+}
+
+// Func syncs a single Cluster, identified by namespace/name. ClusterReconciler's
+// SyncRolesDrift satisfies this signature.
+type Func func(ctx context.Context, cluster types.NamespacedName) error
+
+// Syncer periodically enqueues every Cluster's namespace/name and calls Sync once per
+// dequeue, deduping in-flight work via a rate-limiting workqueue keyed by
+// namespace/name. It implements manager.Runnable so it starts and stops alongside a
+// manager's other controllers.
+type Syncer struct {
+	// Client lists Clusters to enqueue. Set by AddToManager.
+	Client client.Client
+	// Sync is called once per dequeued Cluster key.
+	Sync Func
+	// Options configures the enqueue interval.
+	Options Options
+
+	queue workqueue.RateLimitingInterface
+}
+
+// AddToManager registers s with mgr: s.Client is set from mgr's client, and s is added
+// as a manager.Runnable so mgr.Start runs it alongside the manager's controllers.
+func AddToManager(mgr manager.Manager, s *Syncer) error {
+	s.Client = mgr.GetClient()
+	return mgr.Add(s)
+}
+
+// Start implements manager.Runnable. It enqueues every Cluster immediately, then again
+// every Options.Interval, and blocks until ctx is done.
+func (s *Syncer) Start(ctx context.Context) error {
+	interval := s.Options.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	s.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer s.queue.ShutDown()
+
+	go s.runWorker(ctx)
+
+	s.enqueueAll(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.enqueueAll(ctx)
+		}
+	}
+}
+
+// enqueueAll lists every Cluster and adds its namespace/name to the queue. The
+// workqueue itself dedupes: adding a key already queued (and not yet Get'd) is a no-op.
+func (s *Syncer) enqueueAll(ctx context.Context) {
+	clusterList := &tarantooliov1alpha1.ClusterList{}
+	if err := s.Client.List(ctx, clusterList); err != nil {
+		return
+	}
+
+	for _, cluster := range clusterList.Items {
+		s.queue.Add(types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()})
+	}
+}
+
+func (s *Syncer) runWorker(ctx context.Context) {
+	for s.processNextItem(ctx) {
+	}
+}
+
+// processNextItem dequeues one key and calls Sync, returning false once the queue has
+// been shut down (Start's ctx is done).
+func (s *Syncer) processNextItem(ctx context.Context) bool {
+	key, shutdown := s.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer s.queue.Done(key)
+
+	if err := s.Sync(ctx, key.(types.NamespacedName)); err != nil {
+		s.queue.AddRateLimited(key)
+		return true
+	}
+
+	s.queue.Forget(key)
+	return true
+}
@@ -0,0 +1,115 @@
+package sync
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tarantooliov1alpha1 "github.com/tarantool/tarantool-operator/api/v1alpha1"
+)
+
+func newSyncScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = tarantooliov1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func TestSyncer_EnqueuesAndSyncsEveryCluster(t *testing.T) {
+	clusterA := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"}}
+	clusterB := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test"}}
+
+	var synced int32
+	seen := make(chan types.NamespacedName, 2)
+
+	s := &Syncer{
+		Client: fake.NewClientBuilder().WithScheme(newSyncScheme()).WithObjects(clusterA, clusterB).Build(),
+		Sync: func(ctx context.Context, key types.NamespacedName) error {
+			atomic.AddInt32(&synced, 1)
+			seen <- key
+			return nil
+		},
+		Options: Options{Interval: time.Hour},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Start(ctx) }()
+
+	got := map[types.NamespacedName]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case key := <-seen:
+			got[key] = true
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for Sync to be called for both Clusters, got %v so far", got)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start returned an error: %s", err)
+	}
+
+	want := map[types.NamespacedName]bool{
+		{Namespace: "test", Name: "a"}: true,
+		{Namespace: "test", Name: "b"}: true,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Sync called for %v, want %v", got, want)
+	}
+	for key := range want {
+		if !got[key] {
+			t.Errorf("expected Sync to be called for %v, got %v", key, got)
+		}
+	}
+}
+
+func TestSyncer_FailedSyncIsRetried(t *testing.T) {
+	cluster := &tarantooliov1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "retry-me", Namespace: "test"}}
+
+	var attempts int32
+	done := make(chan struct{})
+
+	s := &Syncer{
+		Client: fake.NewClientBuilder().WithScheme(newSyncScheme()).WithObjects(cluster).Build(),
+		Sync: func(ctx context.Context, key types.NamespacedName) error {
+			if n := atomic.AddInt32(&attempts, 1); n < 2 {
+				return fmt.Errorf("synthetic failure on attempt %d", n)
+			}
+			close(done)
+			return nil
+		},
+		Options: Options{Interval: time.Hour},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = s.Start(ctx) }()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the failed sync to be retried, got %d attempt(s)", atomic.LoadInt32(&attempts))
+	}
+}
+
+func TestOptions_BindFlags_DefaultsInterval(t *testing.T) {
+	var opts Options
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.BindFlags(fs)
+
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("parsing flags: %s", err)
+	}
+	if opts.Interval != defaultInterval {
+		t.Fatalf("Interval = %s, want default %s", opts.Interval, defaultInterval)
+	}
+}
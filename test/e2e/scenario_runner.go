@@ -0,0 +1,295 @@
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/tarantool/tarantool-operator/controllers/kubeutil"
+	"github.com/tarantool/tarantool-operator/controllers/leaderlease"
+	"github.com/tarantool/tarantool-operator/controllers/tarantool"
+)
+
+// Scenario is a declarative e2e test case, loaded from a scenario.yaml file under
+// test/e2e/scenarios/<name>/: a set of manifests to apply, a list of assertions to
+// wait on, and optional mutations to apply afterward (e.g. to exercise failover).
+type Scenario struct {
+	// Apply lists manifest files, relative to the scenario's own directory, applied
+	// in order.
+	Apply []string `json:"apply"`
+	// Wait lists typed assertions run in order after every Apply manifest is created.
+	Wait []WaitStep `json:"wait"`
+	// Mutate lists state changes run in order after Wait, e.g. to force a re-election.
+	Mutate []MutateStep `json:"mutate"`
+}
+
+// WaitStep is a single typed assertion a scenario polls for. Type selects which of
+// Count/Cluster/Role runWaitStep reads; see its switch for the supported types.
+type WaitStep struct {
+	Type     string   `json:"type"`
+	Count    int      `json:"count,omitempty"`
+	Cluster  string   `json:"cluster,omitempty"`
+	Role     string   `json:"role,omitempty"`
+	Timeout  Duration `json:"timeout"`
+	Interval Duration `json:"interval"`
+}
+
+// MutateStep is a single state change a scenario applies mid-run. Type selects which
+// of Target/Addresses runMutateStep reads; see its switch for the supported types.
+type MutateStep struct {
+	Type      string   `json:"type"`
+	Target    string   `json:"target"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// Duration unmarshals a Go duration literal ("60s") from YAML/JSON; time.Duration's
+// own JSON form is an opaque integer of nanoseconds, which isn't what a scenario
+// author wants to write by hand.
+type Duration struct{ time.Duration }
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// LoadScenario parses dir's scenario.yaml into a Scenario.
+func LoadScenario(dir string) (*Scenario, error) {
+	b, err := os.ReadFile(filepath.Join(dir, "scenario.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("reading scenario.yaml: %w", err)
+	}
+
+	scenario := &Scenario{}
+	if err := yaml.Unmarshal(b, scenario); err != nil {
+		return nil, fmt.Errorf("parsing scenario.yaml: %w", err)
+	}
+	return scenario, nil
+}
+
+// RunScenario executes scenario's Apply, Wait and Mutate stages in order against
+// namespace, logging each stage's duration to t as it completes. dir is the
+// scenario's own directory, used to resolve Apply's manifest paths.
+func RunScenario(ctx context.Context, t *testing.T, c client.Client, scenario *Scenario, dir, namespace string) error {
+	if err := runStage(t, "apply", func() error {
+		return applyManifests(ctx, c, scenario.Apply, dir, namespace)
+	}); err != nil {
+		return err
+	}
+
+	for _, step := range scenario.Wait {
+		step := step
+		if err := runStage(t, fmt.Sprintf("wait:%s", step.Type), func() error {
+			return runWaitStep(ctx, c, step, namespace)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, step := range scenario.Mutate {
+		step := step
+		if err := runStage(t, fmt.Sprintf("mutate:%s", step.Type), func() error {
+			return runMutateStep(ctx, c, step, namespace)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runStage times fn and logs its duration to t under name before returning fn's error.
+func runStage(t *testing.T, name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	t.Logf("scenario stage %s took %s", name, time.Since(start))
+	return err
+}
+
+// applyManifests decodes each YAML manifest in files (resolved relative to dir) and
+// creates it in namespace, via kubeutil so a create that loses a race against another
+// actor retries instead of failing the scenario outright.
+func applyManifests(ctx context.Context, c client.Client, files []string, dir, namespace string) error {
+	for _, file := range files {
+		if err := applyManifest(ctx, c, filepath.Join(dir, file), namespace); err != nil {
+			return fmt.Errorf("applying manifest %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func applyManifest(ctx context.Context, c client.Client, path, namespace string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := k8syaml.NewYAMLReader(bufio.NewReader(f))
+	for {
+		raw, err := dec.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		spec, err := yaml.YAMLToJSON(raw)
+		if err != nil {
+			return err
+		}
+		if len(spec) == 0 || string(spec) == "null" {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(spec); err != nil {
+			return err
+		}
+		obj.SetNamespace(namespace)
+
+		if err := kubeutil.CreateOrGetWithRetry(ctx, c, obj); err != nil {
+			return err
+		}
+	}
+}
+
+// runWaitStep polls until step's assertion holds or step.Timeout elapses.
+func runWaitStep(ctx context.Context, c client.Client, step WaitStep, namespace string) error {
+	var check func() (bool, error)
+
+	switch step.Type {
+	case "stsCount":
+		check = func() (bool, error) { return stsCountReached(ctx, c, namespace, step.Count) }
+	case "leaderElected":
+		check = func() (bool, error) { return leaderElected(ctx, c, namespace, step.Cluster) }
+	case "roleJoined":
+		check = func() (bool, error) { return roleJoined(ctx, c, namespace, step.Role) }
+	case "vshardBootstrapped":
+		check = func() (bool, error) { return vshardBootstrapped(ctx, c, namespace) }
+	default:
+		return fmt.Errorf("unknown wait type %q", step.Type)
+	}
+
+	return wait.PollImmediate(step.Interval.Duration, step.Timeout.Duration, check)
+}
+
+func stsCountReached(ctx context.Context, c client.Client, namespace string, count int) (bool, error) {
+	stsList := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, stsList, client.InNamespace(namespace)); err != nil {
+		return false, err
+	}
+	return len(stsList.Items) == count, nil
+}
+
+func leaderElected(ctx context.Context, c client.Client, namespace, cluster string) (bool, error) {
+	lease := &coordinationv1.Lease{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: cluster}, lease); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return leaderlease.IsHeld(lease, time.Now()), nil
+}
+
+func roleJoined(ctx context.Context, c client.Client, namespace, role string) (bool, error) {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabels{"tarantool.io/role": role}); err != nil {
+		return false, err
+	}
+	if len(podList.Items) == 0 {
+		return false, nil
+	}
+	for i := range podList.Items {
+		if !tarantool.IsJoined(&podList.Items[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func vshardBootstrapped(ctx context.Context, c client.Client, namespace string) (bool, error) {
+	stsList := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, stsList, client.InNamespace(namespace)); err != nil {
+		return false, err
+	}
+	if len(stsList.Items) == 0 {
+		return false, nil
+	}
+	for i := range stsList.Items {
+		if stsList.Items[i].GetAnnotations()["tarantool.io/isBootstrapped"] != "1" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// runMutateStep applies a single mid-scenario state change.
+func runMutateStep(ctx context.Context, c client.Client, step MutateStep, namespace string) error {
+	switch step.Type {
+	case "endpointsSetAddresses":
+		return endpointsSetAddresses(ctx, c, namespace, step.Target, step.Addresses)
+	case "expireLease":
+		return expireLease(ctx, c, namespace, step.Target)
+	default:
+		return fmt.Errorf("unknown mutate type %q", step.Type)
+	}
+}
+
+// endpointsSetAddresses overwrites the Endpoints named target's address list, e.g. to
+// simulate every Pod behind a Cluster's Service being replaced.
+func endpointsSetAddresses(ctx context.Context, c client.Client, namespace, target string, addresses []string) error {
+	ep := &corev1.Endpoints{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: target}, ep); err != nil {
+		return err
+	}
+
+	return kubeutil.UpdateWithRetry(ctx, c, ep, func(o client.Object) error {
+		updated := o.(*corev1.Endpoints)
+		epAddresses := make([]corev1.EndpointAddress, 0, len(addresses))
+		for _, addr := range addresses {
+			epAddresses = append(epAddresses, corev1.EndpointAddress{IP: addr})
+		}
+		updated.Subsets = []corev1.EndpointSubset{{Addresses: epAddresses}}
+		return nil
+	})
+}
+
+// expireLease backdates the leader Lease named target so its holder reads as expired,
+// forcing the next reconcile to elect a new one.
+func expireLease(ctx context.Context, c client.Client, namespace, target string) error {
+	lease := &coordinationv1.Lease{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: target}, lease); err != nil {
+		return err
+	}
+
+	return kubeutil.UpdateWithRetry(ctx, c, lease, func(o client.Object) error {
+		updated := o.(*coordinationv1.Lease)
+		past := metav1.NewMicroTime(time.Now().Add(-1 * time.Hour))
+		updated.Spec.RenewTime = &past
+		return nil
+	})
+}
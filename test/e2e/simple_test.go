@@ -1,26 +1,35 @@
 package e2e
 
 import (
+	goctx "context"
 	"testing"
 	"time"
 
 	framework "github.com/operator-framework/operator-sdk/pkg/test"
 	"github.com/operator-framework/operator-sdk/pkg/test/e2eutil"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/tarantool/tarantool-operator/controllers/leaderlease"
 )
 
-func TestOperatorMustCreateStatefulSetPerCartridgeRole(t *testing.T) {
+const (
+	basicScenarioDir          = "test/e2e/scenarios/basic"
+	leaderFailoverScenarioDir = "test/e2e/scenarios/leader-failover"
+)
+
+// setupOperator provisions a fresh namespace and waits for the operator Deployment to
+// come up in it, returning the test context (for cleanup) and the namespace.
+func setupOperator(t *testing.T) (*framework.TestCtx, string) {
 	ctx := framework.NewTestCtx(t)
-	defer ctx.Cleanup()
 
-	clenupOpts := &framework.CleanupOptions{
+	cleanupOpts := &framework.CleanupOptions{
 		TestContext:   ctx,
 		Timeout:       time.Second * 60,
 		RetryInterval: time.Second * 1,
 	}
-	if err := ctx.InitializeClusterResources(clenupOpts); err != nil {
+	if err := ctx.InitializeClusterResources(cleanupOpts); err != nil {
 		t.Fatalf("failed to initialize cluster resources: %v", err)
 	}
 	t.Log("Initialized cluster resources")
@@ -31,32 +40,66 @@ func TestOperatorMustCreateStatefulSetPerCartridgeRole(t *testing.T) {
 	}
 
 	kubeClient := framework.Global.KubeClient
-	err = e2eutil.WaitForOperatorDeployment(t, kubeClient, namespace, "tarantool-operator", 1, time.Second*1, time.Second*60)
-	if err != nil {
+	if err := e2eutil.WaitForOperatorDeployment(t, kubeClient, namespace, "tarantool-operator", 1, time.Second*1, time.Second*60); err != nil {
 		t.Fatalf("failed to deploy operator %s", err)
 	}
 
-	if err = InitializeScenario(ctx, "basic"); err != nil {
-		t.Fatalf("failed to initialize scenario %s", err)
+	return ctx, namespace
+}
+
+func TestOperatorMustCreateStatefulSetPerCartridgeRole(t *testing.T) {
+	ctx, namespace := setupOperator(t)
+	defer ctx.Cleanup()
+
+	scenario, err := LoadScenario(basicScenarioDir)
+	if err != nil {
+		t.Fatalf("failed to load scenario: %s", err)
 	}
 
-	expectedRoles := 2
-	err = wait.Poll(time.Second*1, time.Second*60, func() (done bool, err error) {
-		sts, err := kubeClient.AppsV1().StatefulSets(namespace).List(metav1.ListOptions{})
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				return false, nil
-			}
-			return false, err
-		}
+	if err := RunScenario(goctx.TODO(), t, framework.Global.Client, scenario, basicScenarioDir, namespace); err != nil {
+		t.Fatalf("scenario failed: %s", err)
+	}
+}
 
-		if len(sts.Items) == expectedRoles {
-			return true, nil
-		}
+// TestOperatorElectsNewLeaderWhenPreviousIsGone mirrors the "change the leader if the
+// previous one does not exist" envtest case at e2e scale: once a leader is elected, every
+// address behind the Cluster's Endpoints is replaced and the leader Lease is backdated,
+// and the operator is expected to elect a different leader.
+func TestOperatorElectsNewLeaderWhenPreviousIsGone(t *testing.T) {
+	ctx, namespace := setupOperator(t)
+	defer ctx.Cleanup()
+
+	scenario, err := LoadScenario(leaderFailoverScenarioDir)
+	if err != nil {
+		t.Fatalf("failed to load scenario: %s", err)
+	}
+
+	goCtx := goctx.TODO()
+	c := framework.Global.Client
 
-		return false, nil
+	setup := &Scenario{Apply: scenario.Apply, Wait: scenario.Wait}
+	if err := RunScenario(goCtx, t, c, setup, leaderFailoverScenarioDir, namespace); err != nil {
+		t.Fatalf("scenario setup failed: %s", err)
+	}
+
+	lease := &coordinationv1.Lease{}
+	if err := c.Get(goCtx, types.NamespacedName{Namespace: namespace, Name: "leader-failover"}, lease); err != nil {
+		t.Fatalf("failed to get leader lease: %s", err)
+	}
+	oldLeader := *lease.Spec.HolderIdentity
+
+	failover := &Scenario{Mutate: scenario.Mutate}
+	if err := RunScenario(goCtx, t, c, failover, leaderFailoverScenarioDir, namespace); err != nil {
+		t.Fatalf("scenario failover failed: %s", err)
+	}
+
+	err = wait.PollImmediate(time.Second, 2*time.Minute, func() (bool, error) {
+		if err := c.Get(goCtx, types.NamespacedName{Namespace: namespace, Name: "leader-failover"}, lease); err != nil {
+			return false, nil
+		}
+		return leaderlease.IsHeld(lease, time.Now()) && *lease.Spec.HolderIdentity != oldLeader, nil
 	})
 	if err != nil {
-		t.Fatal(err)
+		t.Fatalf("leader was not re-elected: %s", err)
 	}
 }